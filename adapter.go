@@ -0,0 +1,276 @@
+// Package logger...
+//
+// Description : adapter 标准库log、io.Writer、子进程输出转换为日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 7:00 上午
+package logger
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// defaultMaxLineLength 按行记录日志时单行的默认最大长度
+	defaultMaxLineLength = 64 * 1024
+)
+
+// RedirectStdLog 将标准库log的输出按指定级别记录到日志实例, 返回恢复原有输出的方法
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:02 上午 2026/10/20
+func RedirectStdLog(l *zap.Logger, level zapcore.Level, field ...zap.Field) (func(), error) {
+	return zap.RedirectStdLogAt(l.With(field...), level)
+}
+
+// LineWriterOption 按行记录日志的writer配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:04 上午 2026/10/20
+type LineWriterOption struct {
+	FieldList     []zap.Field                                                    // 每一行日志携带的字段
+	FormatLine    func(line string, level zapcore.Level) (string, zapcore.Level) // 格式化每一行, 可以调整日志级别, 返回空字符串时忽略该行
+	MaxLineLength int                                                            // 单行的最大长度, 超出时拆分为多条日志
+	SplitOnCR     bool                                                           // \r 是否也作为换行, 如进度条的输出
+}
+
+// SetLineWriterOptionFunc 设置按行记录日志的writer配置
+type SetLineWriterOptionFunc func(o *LineWriterOption)
+
+// WithLineField 设置每一行日志携带的字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:06 上午 2026/10/20
+func WithLineField(fieldList ...zap.Field) SetLineWriterOptionFunc {
+	return func(o *LineWriterOption) {
+		o.FieldList = append(o.FieldList, fieldList...)
+	}
+}
+
+// WithLineFormatter 设置格式化每一行的方法
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:07 上午 2026/10/20
+func WithLineFormatter(formatLine func(line string, level zapcore.Level) (string, zapcore.Level)) SetLineWriterOptionFunc {
+	return func(o *LineWriterOption) {
+		if nil == formatLine {
+			return
+		}
+		o.FormatLine = formatLine
+	}
+}
+
+// WithLineMaxLength 设置单行的最大长度, 没有换行的输出超出该长度时拆分为多条日志, 默认64KB
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:20 下午 2026/10/21
+func WithLineMaxLength(maxLength int) SetLineWriterOptionFunc {
+	return func(o *LineWriterOption) {
+		if maxLength <= 0 {
+			return
+		}
+		o.MaxLineLength = maxLength
+	}
+}
+
+// WithLineSplitOnCR \r 也作为换行, 进度条等使用 \r 刷新的输出每次刷新记录为一条日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:22 下午 2026/10/21
+func WithLineSplitOnCR() SetLineWriterOptionFunc {
+	return func(o *LineWriterOption) {
+		o.SplitOnCR = true
+	}
+}
+
+// NewLineWriter 生成按行记录日志的writer, 每一行记录为一条日志, 不记录调用文件
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:09 上午 2026/10/20
+func NewLineWriter(l *zap.Logger, level zapcore.Level, option ...SetLineWriterOptionFunc) *LineWriter {
+	o := &LineWriterOption{
+		FieldList:     make([]zap.Field, 0),
+		MaxLineLength: defaultMaxLineLength,
+	}
+	for _, f := range option {
+		f(o)
+	}
+	return &LineWriter{
+		loggerInstance: l.WithOptions(zap.WithCaller(false)).With(o.FieldList...),
+		level:          level,
+		option:         o,
+	}
+}
+
+// LineWriter 按行记录日志的writer
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:11 上午 2026/10/20
+type LineWriter struct {
+	lock           sync.Mutex
+	loggerInstance *zap.Logger       // 日志实例
+	level          zapcore.Level     // 日志级别
+	option         *LineWriterOption // 配置
+	buf            bytes.Buffer      // 未结束的行
+}
+
+// Write 按行记录, 未结束的行等待下一次写入或者 Flush, 超出最大长度的部分先行记录
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:13 上午 2026/10/20
+func (lw *LineWriter) Write(p []byte) (int, error) {
+	lw.lock.Lock()
+	defer lw.lock.Unlock()
+	lw.buf.Write(p)
+	lineBreak := "\n"
+	if lw.option.SplitOnCR {
+		lineBreak = "\r\n"
+	}
+	for {
+		data := lw.buf.Bytes()
+		if idx := bytes.IndexAny(data, lineBreak); idx >= 0 && idx <= lw.option.MaxLineLength {
+			lw.writeLine(string(lw.buf.Next(idx + 1)))
+			continue
+		}
+		// 刚好达到最大长度时等待后续数据, 之后的数据可能是换行或者utf8字符剩余的字节
+		if len(data) <= lw.option.MaxLineLength {
+			break
+		}
+		// 不截断在一个utf8字符的中间
+		cut := lw.option.MaxLineLength
+		for cut > 0 && !utf8.RuneStart(data[cut]) {
+			cut--
+		}
+		if cut == 0 {
+			cut = lw.option.MaxLineLength
+		}
+		lw.writeLine(string(lw.buf.Next(cut)))
+	}
+	return len(p), nil
+}
+
+// Flush 记录未结束的行
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:15 上午 2026/10/20
+func (lw *LineWriter) Flush() {
+	lw.lock.Lock()
+	defer lw.lock.Unlock()
+	if lw.buf.Len() > 0 {
+		lw.writeLine(lw.buf.String())
+		lw.buf.Reset()
+	}
+}
+
+// writeLine 记录一行, 空行忽略
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:17 上午 2026/10/20
+func (lw *LineWriter) writeLine(line string) {
+	line, level := strings.TrimRight(line, "\r\n"), lw.level
+	if nil != lw.option.FormatLine {
+		line, level = lw.option.FormatLine(line, level)
+	}
+	if len(strings.TrimSpace(line)) == 0 {
+		return
+	}
+	if ce := lw.loggerInstance.Check(level, line); nil != ce {
+		ce.Write()
+	}
+}
+
+// CommandOption 子进程输出记录的配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:20 上午 2026/10/20
+type CommandOption struct {
+	StdoutLevel zapcore.Level // 标准输出的日志级别
+	StderrLevel zapcore.Level // 标准错误的日志级别
+	FieldList   []zap.Field   // 每一行日志携带的字段
+}
+
+// SetCommandOptionFunc 设置子进程输出记录的配置
+type SetCommandOptionFunc func(o *CommandOption)
+
+// WithStdoutLevel 设置标准输出的日志级别, 默认Info
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:22 上午 2026/10/20
+func WithStdoutLevel(level zapcore.Level) SetCommandOptionFunc {
+	return func(o *CommandOption) {
+		o.StdoutLevel = level
+	}
+}
+
+// WithStderrLevel 设置标准错误的日志级别, 默认Warn
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:23 上午 2026/10/20
+func WithStderrLevel(level zapcore.Level) SetCommandOptionFunc {
+	return func(o *CommandOption) {
+		o.StderrLevel = level
+	}
+}
+
+// WithCommandField 设置子进程每一行日志携带的字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:24 上午 2026/10/20
+func WithCommandField(fieldList ...zap.Field) SetCommandOptionFunc {
+	return func(o *CommandOption) {
+		o.FieldList = append(o.FieldList, fieldList...)
+	}
+}
+
+// RunCommand 运行子进程, 标准输出与标准错误的每一行记录为一条日志, 携带 command、stream 字段, 返回子进程的运行结果
+//
+// \r 同样作为换行, 单行超出64KB时拆分为多条日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:27 上午 2026/10/20
+func RunCommand(l *zap.Logger, cmd *exec.Cmd, option ...SetCommandOptionFunc) error {
+	o := &CommandOption{
+		StdoutLevel: zapcore.InfoLevel,
+		StderrLevel: zapcore.WarnLevel,
+		FieldList:   make([]zap.Field, 0),
+	}
+	for _, f := range option {
+		f(o)
+	}
+	command := strings.Join(cmd.Args, " ")
+	if len(cmd.Args) == 0 {
+		command = cmd.Path
+	}
+	fieldList := append([]zap.Field{zap.String("command", command)}, o.FieldList...)
+	stdout := NewLineWriter(l, o.StdoutLevel, WithLineField(fieldList...), WithLineField(zap.String("stream", "stdout")), WithLineSplitOnCR())
+	stderr := NewLineWriter(l, o.StderrLevel, WithLineField(fieldList...), WithLineField(zap.String("stream", "stderr")), WithLineSplitOnCR())
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	err := cmd.Run()
+	stdout.Flush()
+	stderr.Flush()
+	return err
+}
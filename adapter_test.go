@@ -0,0 +1,96 @@
+// Package logger...
+//
+// Description : adapter_test 标准库log、按行记录的writer以及子进程输出的单元测试
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 7:35 上午
+package logger
+
+import (
+	"log"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Test_Adapter 测试标准库log、按行记录的writer以及子进程输出
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:35 上午 2026/10/20
+func Test_Adapter(t *testing.T) {
+	l, buf := newTestLogger()
+	restore, err := RedirectStdLog(l, zapcore.WarnLevel, zap.String("source", "std"))
+	if nil != err {
+		t.Fatalf("标准库log重定向失败 : %v", err)
+	}
+	log.Print("std log")
+	restore()
+	data := decodeTestLine(t, buf.String())
+	if data["message"] != "std log" || data["level"] != "WARN" || data["source"] != "std" {
+		t.Fatalf("标准库log重定向错误 : %s", buf.String())
+	}
+
+	buf.Reset()
+	writer := NewLineWriter(l, zapcore.InfoLevel, WithLineField(zap.String("source", "writer")))
+	_, _ = writer.Write([]byte("line 1\nline"))
+	_, _ = writer.Write([]byte(" 2\r\n\nline 3"))
+	writer.Flush()
+	lineList := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lineList) != 3 || decodeTestLine(t, lineList[1])["message"] != "line 2" || decodeTestLine(t, lineList[2])["source"] != "writer" {
+		t.Fatalf("按行记录日志错误 : %s", buf.String())
+	}
+
+	buf.Reset()
+	writer = NewLineWriter(l, zapcore.InfoLevel, WithLineMaxLength(4))
+	_, _ = writer.Write([]byte("abcdef中文\n"))
+	lineList = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lineList) != 4 || decodeTestLine(t, lineList[0])["message"] != "abcd" || decodeTestLine(t, lineList[2])["message"] != "中" {
+		t.Fatalf("超出最大长度的行拆分错误 : %s", buf.String())
+	}
+
+	// 刚好达到最大长度的数据
+	buf.Reset()
+	writer = NewLineWriter(l, zapcore.InfoLevel, WithLineMaxLength(4))
+	_, _ = writer.Write([]byte("abcd"))
+	_, _ = writer.Write([]byte("\nab"))
+	_, _ = writer.Write([]byte("c\xe4"))
+	_, _ = writer.Write([]byte("\xb8\xad"))
+	writer.Flush()
+	lineList = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lineList) != 3 || decodeTestLine(t, lineList[0])["message"] != "abcd" || decodeTestLine(t, lineList[1])["message"] != "abc" || decodeTestLine(t, lineList[2])["message"] != "中" {
+		t.Fatalf("刚好达到最大长度的行拆分错误 : %s", buf.String())
+	}
+
+	buf.Reset()
+	writer = NewLineWriter(l, zapcore.InfoLevel)
+	_, _ = writer.Write([]byte(strings.Repeat("x", defaultMaxLineLength/2)))
+	_, _ = writer.Write([]byte(strings.Repeat("x", defaultMaxLineLength/2)))
+	_, _ = writer.Write([]byte("\n"))
+	if lineList = strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lineList) != 1 || len(decodeTestLine(t, lineList[0])["message"].(string)) != defaultMaxLineLength {
+		t.Fatalf("分多次写入刚好达到最大长度的行错误 : %d", len(lineList))
+	}
+
+	buf.Reset()
+	if err := RunCommand(l, exec.Command("sh", "-c", "printf '10%%\r50%%\r100%%\n'")); nil != err {
+		t.Fatalf("子进程运行失败 : %v", err)
+	}
+	if lineList = strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lineList) != 3 || decodeTestLine(t, lineList[1])["message"] != "50%" {
+		t.Fatalf("子进程输出的 \\r 未作为换行 : %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := RunCommand(l, exec.Command("sh", "-c", "echo out; echo err 1>&2; exit 3")); nil == err {
+		t.Fatalf("子进程运行结果错误")
+	}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		data := decodeTestLine(t, line)
+		if data["command"] != "sh -c echo out; echo err 1>&2; exit 3" || (data["stream"] == "stderr") != (data["level"] == "WARN") || data["message"] != strings.TrimPrefix(data["stream"].(string), "std") {
+			t.Fatalf("子进程输出记录错误 : %s", buf.String())
+		}
+	}
+}
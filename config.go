@@ -233,14 +233,29 @@ func defaultEncodeDuration(d time.Duration, enc zapcore.PrimitiveArrayEncoder) {
 //
 // Date : 11:41 下午 2021/1/2
 type OptionLogger struct {
-	UseJsonFormat  bool                    // 日志使用json格式
-	MessageKey     string                  // message 字段
-	LevelKey       string                  // level 字段
-	TimeKey        string                  // 时间字段
-	CallerKey      string                  // 记录日志的文件的代码行数
-	UseShortCaller bool                    // 使用短的调用文件格式
-	TimeEncoder    zapcore.TimeEncoder     // 格式化时间的函数
-	EncodeDuration zapcore.DurationEncoder // 原始时间信息
+	UseJsonFormat        bool                                                       // 日志使用json格式
+	MessageKey           string                                                     // message 字段
+	LevelKey             string                                                     // level 字段
+	TimeKey              string                                                     // 时间字段
+	CallerKey            string                                                     // 记录日志的文件的代码行数
+	NameKey              string                                                     // 日志实例名称字段
+	StacktraceKey        string                                                     // 堆栈字段
+	UseShortCaller       bool                                                       // 使用短的调用文件格式
+	TimeEncoder          zapcore.TimeEncoder                                        // 格式化时间的函数
+	LevelEncoder         zapcore.LevelEncoder                                       // 格式化日志级别的函数
+	EncodeDuration       zapcore.DurationEncoder                                    // 原始时间信息
+	MaxStringLength      int                                                        // 字符串字段的最大长度, 0 - 不限制
+	MaxArrayLength       int                                                        // 数组字段的最大元素数量, 0 - 不限制
+	MaxDepth             int                                                        // 字段的最大嵌套深度, 0 - 不限制
+	MaxEntryBytes        int                                                        // 单条日志的最大字节数, 0 - 不限制
+	StaticFieldList      []zapcore.Field                                            // 每条日志都会携带的静态字段
+	StaticFieldNamespace string                                                     // 静态字段放入的对象key, 为空放在顶层
+	FieldNamespace       string                                                     // 用户字段放入的对象key, 为空放在顶层
+	FormatStaticFieldKey func(key string) string                                    // 转换静态字段的key
+	FormatFieldKey       func(key string) string                                    // 转换用户字段的key
+	FormatFieldList      func(fieldList []zapcore.Field) []zapcore.Field            // 转换静态字段与用户字段, 在转换key之前执行
+	EntryFieldFunc       func(ent zapcore.Entry) ([]zapcore.Field, []zapcore.Field) // 根据日志本身生成的附加字段, 分别放在顶层与用户字段中
+	DuplicateKeyPolicy   DuplicateKeyPolicy                                         // 字段key重复时的处理策略
 }
 
 // 设置日志配置
@@ -342,6 +357,20 @@ func WithTimeEncoder(encoder zapcore.TimeEncoder) SetLoggerOptionFunc {
 	}
 }
 
+// WithLevelEncoder 设置格式化日志级别的方法
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:46 下午 2026/10/19
+func WithLevelEncoder(encoder zapcore.LevelEncoder) SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		if nil == encoder {
+			return
+		}
+		o.LevelEncoder = encoder
+	}
+}
+
 // WithEncodeDuration 原始时间
 //
 // Author : go_developer@163.com<张德满>
@@ -356,6 +385,77 @@ func WithEncodeDuration(encoder zapcore.DurationEncoder) SetLoggerOptionFunc {
 	}
 }
 
+// WithMaxStringLength 字符串字段的最大长度, 超出部分截断
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:10 上午 2026/10/19
+func WithMaxStringLength(maxLength int) SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		if maxLength <= 0 {
+			return
+		}
+		o.MaxStringLength = maxLength
+	}
+}
+
+// WithMaxArrayLength 数组字段的最大元素数量, 超出部分截断
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:11 上午 2026/10/19
+func WithMaxArrayLength(maxLength int) SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		if maxLength <= 0 {
+			return
+		}
+		o.MaxArrayLength = maxLength
+	}
+}
+
+// WithMaxDepth 字段的最大嵌套深度, 超出深度的数据截断
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:12 上午 2026/10/19
+func WithMaxDepth(maxDepth int) SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		if maxDepth <= 0 {
+			return
+		}
+		o.MaxDepth = maxDepth
+	}
+}
+
+// WithMaxEntryBytes 单条日志的最大字节数, 超出时丢弃放不下的字段, 日志本身不会被丢弃
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:13 上午 2026/10/19
+func WithMaxEntryBytes(maxBytes int) SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		if maxBytes <= 0 {
+			return
+		}
+		o.MaxEntryBytes = maxBytes
+	}
+}
+
+// WithFieldNamespace 用户字段统一放入指定key的对象中, 避免与message/level等保留字段冲突, 静态字段依旧在顶层
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:20 下午 2026/10/19
+func WithFieldNamespace(namespace string) SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		namespace = strings.Trim(namespace, " ")
+		if len(namespace) == 0 {
+			return
+		}
+		o.FieldNamespace = namespace
+	}
+}
+
 // GetEncoder 获取空中台输出的encoder
 //
 // Author : go_developer@163.com<张德满>
@@ -371,6 +471,7 @@ func GetEncoder(option ...SetLoggerOptionFunc) zapcore.Encoder {
 		CallerKey:      defaultCallerKey,
 		EncodeDuration: defaultEncodeDuration,
 		UseShortCaller: defaultUseShortCaller,
+		LevelEncoder:   zapcore.CapitalLevelEncoder,
 	}
 	for _, o := range option {
 		o(ol)
@@ -378,18 +479,38 @@ func GetEncoder(option ...SetLoggerOptionFunc) zapcore.Encoder {
 	ec := zapcore.EncoderConfig{
 		MessageKey:     ol.MessageKey,
 		LevelKey:       ol.LevelKey,
-		EncodeLevel:    zapcore.CapitalLevelEncoder,
+		EncodeLevel:    ol.LevelEncoder,
 		TimeKey:        ol.TimeKey,
 		EncodeTime:     ol.TimeEncoder,
 		CallerKey:      ol.CallerKey,
+		NameKey:        ol.NameKey,
+		StacktraceKey:  ol.StacktraceKey,
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 		EncodeDuration: ol.EncodeDuration,
 	}
 	if !ol.UseShortCaller {
 		ec.EncodeCaller = zapcore.FullCallerEncoder
 	}
+	var encoder zapcore.Encoder
 	if !ol.UseJsonFormat {
-		return zapcore.NewConsoleEncoder(ec)
+		encoder = zapcore.NewConsoleEncoder(ec)
+	} else {
+		encoder = zapcore.NewJSONEncoder(ec)
+	}
+	if !ol.needFormatField() {
+		return encoder
 	}
-	return zapcore.NewJSONEncoder(ec)
+	return newFieldEncoder(encoder, ec, ol)
+}
+
+// needFormatField 是否需要在输出前处理字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:16 上午 2026/10/19
+func (o *OptionLogger) needFormatField() bool {
+	return o.hasValueLimit() || o.MaxEntryBytes > 0 || len(o.StaticFieldList) > 0 ||
+		len(o.StaticFieldNamespace) > 0 || len(o.FieldNamespace) > 0 ||
+		nil != o.FormatStaticFieldKey || nil != o.FormatFieldKey || nil != o.FormatFieldList || nil != o.EntryFieldFunc ||
+		o.DuplicateKeyPolicy != DuplicateKeyPolicyNone
 }
@@ -0,0 +1,145 @@
+// Package logger...
+//
+// Description : context 在 context.Context 中传递日志实例与字段, 只能拿到 context.Context 的代码也可以使用请求的日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 2:45 上午
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// loggerContextKey 日志实例在 context.Context 中的key
+type loggerContextKey struct{}
+
+// fieldContextKey 日志字段在 context.Context 中的key
+type fieldContextKey struct{}
+
+// ContextExtractor 从 context.Context 中抽取日志字段的方法
+type ContextExtractor func(ctx context.Context) []zap.Field
+
+var (
+	// contextExtractorLock 保护全局的字段抽取方法列表
+	contextExtractorLock = &sync.RWMutex{}
+	// contextExtractorList 全局的字段抽取方法列表, Ctx 生成日志实例时使用
+	contextExtractorList = make([]ContextExtractor, 0)
+)
+
+// NewContext 生成携带日志实例的 context.Context
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:47 上午 2026/10/20
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	if nil == ctx {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, loggerContextKey{}, l)
+}
+
+// FromContext 获取 context.Context 中的日志实例, 携带 WithContext 设置的字段, 不存在时使用 zap.L()
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:48 上午 2026/10/20
+func FromContext(ctx context.Context) *zap.Logger {
+	l := loggerFromContext(ctx)
+	if nil == ctx {
+		return l
+	}
+	if contextFieldList, ok := ctx.Value(fieldContextKey{}).([]zap.Field); ok && len(contextFieldList) > 0 {
+		return l.With(contextFieldList...)
+	}
+	return l
+}
+
+// loggerFromContext 获取 NewContext 记录的日志实例, 不存在时返回 zap.L()
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:10 下午 2026/10/21
+func loggerFromContext(ctx context.Context) *zap.Logger {
+	if nil != ctx {
+		if l, ok := ctx.Value(loggerContextKey{}).(*zap.Logger); ok && nil != l {
+			return l
+		}
+	}
+	return zap.L()
+}
+
+// WithContext 生成携带日志字段的 context.Context, 已有的字段会保留
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:40 上午 2026/10/20
+func WithContext(ctx context.Context, field ...zap.Field) context.Context {
+	if nil == ctx {
+		ctx = context.Background()
+	}
+	existFieldList, _ := ctx.Value(fieldContextKey{}).([]zap.Field)
+	fieldList := make([]zap.Field, 0, len(existFieldList)+len(field))
+	fieldList = append(append(fieldList, existFieldList...), field...)
+	return context.WithValue(ctx, fieldContextKey{}, fieldList)
+}
+
+// Ctx 获取携带 context.Context 中字段的日志实例, 包括 WithContext 设置的字段以及注册的抽取方法抽取的字段
+//
+// 日志实例为 NewContext 记录的实例, 不存在时使用 zap.L()
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:43 上午 2026/10/20
+func Ctx(ctx context.Context) *zap.Logger {
+	l := loggerFromContext(ctx)
+	if nil == ctx {
+		return l
+	}
+	fieldList := make([]zap.Field, 0)
+	contextExtractorLock.RLock()
+	for _, extractor := range contextExtractorList {
+		fieldList = append(fieldList, extractor(ctx)...)
+	}
+	contextExtractorLock.RUnlock()
+	if contextFieldList, ok := ctx.Value(fieldContextKey{}).([]zap.Field); ok {
+		fieldList = append(fieldList, contextFieldList...)
+	}
+	if len(fieldList) == 0 {
+		return l
+	}
+	return l.With(fieldList...)
+}
+
+// RegisterContextExtractor 注册全局的字段抽取方法
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:46 上午 2026/10/20
+func RegisterContextExtractor(extractorList ...ContextExtractor) {
+	contextExtractorLock.Lock()
+	defer contextExtractorLock.Unlock()
+	for _, extractor := range extractorList {
+		if nil != extractor {
+			contextExtractorList = append(contextExtractorList, extractor)
+		}
+	}
+}
+
+// RegisterContextField 注册全局的字段抽取, context.Context 中 ctxKey 的值记录为 fieldKey 字段, 不存在时忽略
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:48 上午 2026/10/20
+func RegisterContextField(ctxKey interface{}, fieldKey string) {
+	RegisterContextExtractor(func(ctx context.Context) []zap.Field {
+		value := ctx.Value(ctxKey)
+		if nil == value {
+			return nil
+		}
+		return []zap.Field{zap.Any(fieldKey, value)}
+	})
+}
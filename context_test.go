@@ -0,0 +1,63 @@
+// Package logger...
+//
+// Description : context_test context.Context 传递日志实例与字段的单元测试
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 4:50 上午
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// testContextKey 测试使用的 context.Context key
+type testContextKey struct{}
+
+// Test_ContextLogger 测试通过 context.Context 传递日志实例与字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:52 上午 2026/10/20
+func Test_ContextLogger(t *testing.T) {
+	defer resetContextExtractor()()
+	l, buf := newTestLogger()
+	RegisterContextField(testContextKey{}, "tenant")
+	ctx := NewContext(context.Background(), l)
+	ctx = WithContext(ctx, zap.String("job", "sync"))
+	ctx = WithContext(context.WithValue(ctx, testContextKey{}, "t1"), zap.Int("batch", 2))
+	Ctx(ctx).Info("context")
+	data := decodeTestLine(t, buf.String())
+	if data["tenant"] != "t1" || data["job"] != "sync" || data["batch"] != float64(2) {
+		t.Fatalf("context 字段错误 : %s", buf.String())
+	}
+	buf.Reset()
+	FromContext(ctx).Info("from context")
+	data = decodeTestLine(t, buf.String())
+	if nil != data["tenant"] || data["job"] != "sync" || data["batch"] != float64(2) {
+		t.Fatalf("FromContext 字段错误 : %s", buf.String())
+	}
+	if Ctx(context.Background()) != zap.L() {
+		t.Fatalf("context 中没有日志实例时应使用全局实例")
+	}
+}
+
+// resetContextExtractor 测试注册的全局字段抽取方法只在当前测试中生效, 返回恢复原有抽取方法的方法
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:40 下午 2026/10/21
+func resetContextExtractor() func() {
+	contextExtractorLock.Lock()
+	defer contextExtractorLock.Unlock()
+	extractorList := contextExtractorList
+	contextExtractorList = make([]ContextExtractor, 0)
+	return func() {
+		contextExtractorLock.Lock()
+		defer contextExtractorLock.Unlock()
+		contextExtractorList = extractorList
+	}
+}
@@ -0,0 +1,127 @@
+// Package logger...
+//
+// Description : duplicate 字段key重复以及与保留字段冲突时的处理
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 4:40 下午
+package logger
+
+import (
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DuplicateKeyPolicy 字段key重复时的处理策略
+type DuplicateKeyPolicy uint
+
+const (
+	// DuplicateKeyPolicyNone 不做处理, 重复的key都会输出
+	DuplicateKeyPolicyNone = DuplicateKeyPolicy(0)
+	// DuplicateKeyPolicyLastWins 保留最后一次出现的字段
+	DuplicateKeyPolicyLastWins = DuplicateKeyPolicy(1)
+	// DuplicateKeyPolicyFirstWins 保留第一次出现的字段
+	DuplicateKeyPolicyFirstWins = DuplicateKeyPolicy(2)
+	// DuplicateKeyPolicyRename 重复的字段key增加数字后缀, 如 : name_1
+	DuplicateKeyPolicyRename = DuplicateKeyPolicy(3)
+)
+
+// WithDuplicateKeyPolicy 设置字段key重复时的处理策略
+//
+// 设置策略后, 与 message / level / time 等保留字段以及静态字段同名的用户字段会增加数字后缀, 保证保留字段不被覆盖
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:45 下午 2026/10/19
+func WithDuplicateKeyPolicy(policy DuplicateKeyPolicy) SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		o.DuplicateKeyPolicy = policy
+	}
+}
+
+// reservedKeyTable 用户字段不能使用的key, 用户字段放入命名空间时不会冲突
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:48 下午 2026/10/19
+func (o *OptionLogger) reservedKeyTable(topFieldList ...[]zapcore.Field) map[string]bool {
+	result := make(map[string]bool)
+	if len(o.FieldNamespace) > 0 {
+		return result
+	}
+	for _, key := range []string{o.MessageKey, o.LevelKey, o.TimeKey, o.CallerKey, o.NameKey, o.StacktraceKey} {
+		if len(key) > 0 {
+			result[key] = true
+		}
+	}
+	for _, fieldList := range topFieldList {
+		for _, f := range fieldList {
+			if len(f.Key) > 0 {
+				result[f.Key] = true
+			}
+		}
+	}
+	return result
+}
+
+// dedupeFieldList 按策略处理重复的字段key
+//
+// 命名空间之后的字段处于新的作用域, 只在同一作用域内判断是否重复, 命名空间本身重复时只能重命名
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:52 下午 2026/10/19
+func (o *OptionLogger) dedupeFieldList(fieldList []zapcore.Field, reservedKeyTable map[string]bool) []zapcore.Field {
+	var (
+		scope  = 0
+		seen   = make(map[string]int)
+		result = make([]zapcore.Field, 0, len(fieldList))
+	)
+	isUsed := func(key string) bool {
+		if scope == 0 && reservedKeyTable[key] {
+			return true
+		}
+		_, exist := seen[strconv.Itoa(scope)+":"+key]
+		return exist
+	}
+	for _, f := range fieldList {
+		if f.Type == zapcore.SkipType {
+			continue
+		}
+		if scope == 0 && reservedKeyTable[f.Key] {
+			f.Key = uniqueKey(f.Key, isUsed)
+		} else if idx, exist := seen[strconv.Itoa(scope)+":"+f.Key]; exist {
+			switch {
+			case o.DuplicateKeyPolicy == DuplicateKeyPolicyRename,
+				f.Type == zapcore.NamespaceType, result[idx].Type == zapcore.NamespaceType:
+				f.Key = uniqueKey(f.Key, isUsed)
+			case o.DuplicateKeyPolicy == DuplicateKeyPolicyFirstWins:
+				continue
+			case o.DuplicateKeyPolicy == DuplicateKeyPolicyLastWins:
+				result[idx] = zap.Skip()
+			}
+		}
+		seen[strconv.Itoa(scope)+":"+f.Key] = len(result)
+		result = append(result, f)
+		if f.Type == zapcore.NamespaceType {
+			scope++
+		}
+	}
+	return result
+}
+
+// uniqueKey 为key增加数字后缀, 直到不再重复
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:58 下午 2026/10/19
+func uniqueKey(key string, isUsed func(key string) bool) string {
+	for i := 1; ; i++ {
+		newKey := key + "_" + strconv.Itoa(i)
+		if !isUsed(newKey) {
+			return newKey
+		}
+	}
+}
@@ -0,0 +1,466 @@
+// Package logger...
+//
+// Description : encoder 对zap encoder的包装,在输出前对字段做统一处理
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 10:05 上午
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// valueKey 序列化复杂类型字段时使用的key
+const valueKey = "v"
+
+// newFieldEncoder 包装zap的encoder
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:08 上午 2026/10/19
+func newFieldEncoder(base zapcore.Encoder, ec zapcore.EncoderConfig, ol *OptionLogger) zapcore.Encoder {
+	return &fieldEncoder{
+		base: base,
+		// 只输出字段的encoder, 时间、耗时的格式与原始encoder一致
+		value: zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+			EncodeTime:     ec.EncodeTime,
+			EncodeDuration: ec.EncodeDuration,
+			LineEnding:     "\n",
+		}),
+		option:    ol,
+		fieldList: make([]zapcore.Field, 0),
+	}
+}
+
+// fieldEncoder 记录通过With添加的字段,在EncodeEntry时与本次日志的字段一起处理后再交给zap的encoder
+//
+// zap 的 encoder 在 With 时就会把字段序列化进缓冲区, 之后无法再做截断等处理, 所以这里先把字段记录下来
+//
+// # With 的字段在添加时就按配置限制, 数组、对象等复杂类型同时序列化为json, 之后修改原始数据不影响日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:10 上午 2026/10/19
+type fieldEncoder struct {
+	base      zapcore.Encoder // zap 原始的encoder, 不会写入任何字段
+	value     zapcore.Encoder // 序列化复杂类型字段的encoder, 为nil时仅记录字段
+	option    *OptionLogger   // 日志配置
+	fieldList []zapcore.Field // 通过With添加的字段
+}
+
+// Clone 复制encoder
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:12 上午 2026/10/19
+func (fe *fieldEncoder) Clone() zapcore.Encoder {
+	fieldList := make([]zapcore.Field, len(fe.fieldList))
+	copy(fieldList, fe.fieldList)
+	return &fieldEncoder{
+		base:      fe.base,
+		value:     fe.value,
+		option:    fe.option,
+		fieldList: fieldList,
+	}
+}
+
+// EncodeEntry 处理字段后序列化日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:15 上午 2026/10/19
+func (fe *fieldEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	userFieldList := fe.formatFieldList(fields)
+	buf, err := fe.base.EncodeEntry(ent, fe.buildFieldList(ent, nil, userFieldList))
+	if nil != err {
+		return nil, err
+	}
+	if fe.option.MaxEntryBytes > 0 && buf.Len() > fe.option.MaxEntryBytes {
+		originalLen := buf.Len()
+		buf.Free()
+		return fe.clipEntry(ent, userFieldList, originalLen)
+	}
+	return buf, nil
+}
+
+// formatFieldList 合并With的字段与本次日志的字段, 并按配置处理
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:18 上午 2026/10/19
+func (fe *fieldEncoder) formatFieldList(fields []zapcore.Field) []zapcore.Field {
+	// 本次日志的字段先经过一次记录, 把 error / stringer 等类型展开成基础类型
+	recorder := &fieldEncoder{fieldList: make([]zapcore.Field, 0, len(fe.fieldList)+len(fields))}
+	recorder.fieldList = append(recorder.fieldList, fe.fieldList...)
+	for _, f := range fields {
+		f.AddTo(recorder)
+	}
+	fieldList := recorder.fieldList
+	if fe.option.hasValueLimit() {
+		// With 的字段在添加时已经处理过
+		for idx := len(fe.fieldList); idx < len(fieldList); idx++ {
+			fieldList[idx] = fe.option.limitField(fieldList[idx])
+		}
+	}
+	return fieldList
+}
+
+// buildFieldList 生成最终输出的字段列表, 依次为 : 日志自身的附加字段、标记字段、静态字段、用户字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:10 下午 2026/10/19
+func (fe *fieldEncoder) buildFieldList(ent zapcore.Entry, markerFieldList []zapcore.Field, userFieldList []zapcore.Field) []zapcore.Field {
+	var topFieldList, entryUserFieldList []zapcore.Field
+	if nil != fe.option.EntryFieldFunc {
+		topFieldList, entryUserFieldList = fe.option.EntryFieldFunc(ent)
+	}
+	if len(entryUserFieldList) > 0 {
+		userFieldList = append(entryUserFieldList, userFieldList...)
+	}
+	staticFieldList, formatFieldList := fe.option.StaticFieldList, fe.option.FormatFieldList
+	if nil != formatFieldList {
+		staticFieldList, userFieldList = formatFieldList(staticFieldList), formatFieldList(userFieldList)
+	}
+	staticFieldList = nestFieldList(fe.option.StaticFieldNamespace, formatFieldKey(fe.option.FormatStaticFieldKey, staticFieldList))
+	userFieldList = formatFieldKey(fe.option.FormatFieldKey, userFieldList)
+	if fe.option.DuplicateKeyPolicy != DuplicateKeyPolicyNone {
+		userFieldList = fe.option.dedupeFieldList(userFieldList, fe.option.reservedKeyTable(topFieldList, markerFieldList, staticFieldList))
+	}
+	fieldList := make([]zapcore.Field, 0, len(topFieldList)+len(markerFieldList)+len(staticFieldList)+len(userFieldList))
+	fieldList = append(fieldList, topFieldList...)
+	fieldList = append(fieldList, markerFieldList...)
+	fieldList = append(fieldList, staticFieldList...)
+	return append(fieldList, nestFieldList(fe.option.FieldNamespace, userFieldList)...)
+}
+
+// formatFieldKey 转换顶层字段的key, 命名空间之后的字段不在顶层, 不做处理
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:50 下午 2026/10/19
+func formatFieldKey(formatFunc func(key string) string, fieldList []zapcore.Field) []zapcore.Field {
+	if nil == formatFunc || len(fieldList) == 0 {
+		return fieldList
+	}
+	result := make([]zapcore.Field, len(fieldList))
+	copy(result, fieldList)
+	for idx := range result {
+		result[idx].Key = formatFunc(result[idx].Key)
+		if result[idx].Type == zapcore.NamespaceType {
+			break
+		}
+	}
+	return result
+}
+
+// nestFieldList 把字段放入指定key的对象中, key为空时保持不变
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:53 下午 2026/10/19
+func nestFieldList(namespace string, fieldList []zapcore.Field) []zapcore.Field {
+	if len(namespace) == 0 || len(fieldList) == 0 {
+		return fieldList
+	}
+	return []zapcore.Field{zap.Object(namespace, fieldListMarshaler(fieldList))}
+}
+
+// fieldListMarshaler 把字段列表作为一个对象输出
+//
+// zap 的 encoder 在对象内部打开的命名空间不会被关闭, 所以命名空间之后的字段转换为嵌套的对象
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:56 下午 2026/10/19
+type fieldListMarshaler []zapcore.Field
+
+// MarshalLogObject ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:57 下午 2026/10/19
+func (fl fieldListMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for idx, f := range fl {
+		if f.Type == zapcore.NamespaceType {
+			return enc.AddObject(f.Key, fl[idx+1:])
+		}
+		f.AddTo(enc)
+	}
+	return nil
+}
+
+// clipEntry 日志整体超出长度限制时, 截断日志而不是丢弃
+//
+// 依次保留能放下的用户字段, 放不下的字段丢弃, 并在日志中记录被截断的字节数
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:26 上午 2026/10/19
+func (fe *fieldEncoder) clipEntry(ent zapcore.Entry, userFieldList []zapcore.Field, originalLen int) (*buffer.Buffer, error) {
+	maxBytes := fe.option.MaxEntryBytes - truncatedReserveBytes
+	// 标记字段放在最前面, 避免落入命名空间, 计算长度时先用占位字段
+	markerFieldList := []zapcore.Field{zap.Skip()}
+	size, err := fe.entrySize(ent, fe.buildFieldList(ent, markerFieldList, nil))
+	if nil != err {
+		return nil, err
+	}
+	// 不带任何用户字段依旧超长, 先截断堆栈, 再截断message
+	// 转义之后的长度会大于原始长度, 按序列化之后超出的长度截断, 直到能放下为止
+	for _, str := range []*string{&ent.Stack, &ent.Message} {
+		original := *str
+		for limit := len(original); size > maxBytes && limit > 0; {
+			if limit -= size - maxBytes; limit < 0 {
+				limit = 0
+			}
+			*str = truncateString(original, limit)
+			if size, err = fe.entrySize(ent, fe.buildFieldList(ent, markerFieldList, nil)); nil != err {
+				return nil, err
+			}
+		}
+	}
+
+	// 按单个字段序列化之后的长度累加, 不再每个字段都序列化整条日志
+	keepFieldList := make([]zapcore.Field, 0, len(userFieldList))
+	for _, f := range userFieldList {
+		fieldSize := fe.fieldSize(f)
+		if f.Type != zapcore.NamespaceType && size+fieldSize > maxBytes {
+			continue
+		}
+		keepFieldList = append(keepFieldList, f)
+		size += fieldSize
+	}
+	// 字段key转换、重复字段处理等会导致累加的长度与实际不一致, 超长时从后往前丢弃字段
+	for {
+		if size, err = fe.entrySize(ent, fe.buildFieldList(ent, markerFieldList, keepFieldList)); nil != err {
+			return nil, err
+		}
+		if size <= maxBytes || len(keepFieldList) == 0 {
+			break
+		}
+		keepFieldList = keepFieldList[:len(keepFieldList)-1]
+	}
+	markerFieldList[0] = zap.String(defaultTruncatedKey, truncatedMarker(originalLen-size))
+	return fe.base.EncodeEntry(ent, fe.buildFieldList(ent, markerFieldList, keepFieldList))
+}
+
+// entrySize 计算日志序列化之后的长度
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:31 上午 2026/10/19
+func (fe *fieldEncoder) entrySize(ent zapcore.Entry, fieldList []zapcore.Field) (int, error) {
+	buf, err := fe.base.EncodeEntry(ent, fieldList)
+	if nil != err {
+		return 0, err
+	}
+	size := buf.Len()
+	buf.Free()
+	return size, nil
+}
+
+// fieldSize 计算单个字段序列化之后在日志中占用的长度, 包含分隔的逗号
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:15 上午 2026/10/21
+func (fe *fieldEncoder) fieldSize(f zapcore.Field) int {
+	buf, err := fe.value.EncodeEntry(zapcore.Entry{}, []zapcore.Field{f})
+	if nil != err {
+		return 0
+	}
+	// 去掉 {}\n, 加上逗号
+	size := buf.Len() - 2
+	buf.Free()
+	return size
+}
+
+// addField 记录字段, With 的字段按配置限制, 并复制一份可能被调用方修改的数据
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:20 上午 2026/10/21
+func (fe *fieldEncoder) addField(f zapcore.Field) error {
+	if nil == fe.value {
+		fe.fieldList = append(fe.fieldList, f)
+		return nil
+	}
+	if fe.option.hasValueLimit() {
+		f = fe.option.limitField(f)
+	}
+	switch f.Type {
+	case zapcore.ArrayMarshalerType, zapcore.ObjectMarshalerType, zapcore.ReflectType:
+		value, err := fe.encodeValue(f)
+		if nil != err {
+			return err
+		}
+		f = zap.Reflect(f.Key, value)
+	case zapcore.BinaryType, zapcore.ByteStringType:
+		f.Interface = append([]byte(nil), f.Interface.([]byte)...)
+	}
+	fe.fieldList = append(fe.fieldList, f)
+	return nil
+}
+
+// encodeValue 把复杂类型字段的值序列化为json
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:24 上午 2026/10/21
+func (fe *fieldEncoder) encodeValue(f zapcore.Field) (json.RawMessage, error) {
+	enc := fe.value.Clone()
+	var err error
+	switch f.Type {
+	case zapcore.ArrayMarshalerType:
+		err = enc.AddArray(valueKey, f.Interface.(zapcore.ArrayMarshaler))
+	case zapcore.ObjectMarshalerType:
+		err = enc.AddObject(valueKey, f.Interface.(zapcore.ObjectMarshaler))
+	default:
+		err = enc.AddReflected(valueKey, f.Interface)
+	}
+	if nil != err {
+		return nil, err
+	}
+	buf, err := enc.EncodeEntry(zapcore.Entry{}, nil)
+	if nil != err {
+		return nil, err
+	}
+	defer buf.Free()
+	// 输出格式为 {"v":...}\n
+	data := bytes.TrimSuffix(buf.Bytes(), []byte("}\n"))[len(valueKey)+4:]
+	return append(json.RawMessage(nil), data...), nil
+}
+
+// ============== 以下为 zapcore.ObjectEncoder 的实现, 仅记录字段
+
+// AddArray ...
+func (fe *fieldEncoder) AddArray(key string, marshaler zapcore.ArrayMarshaler) error {
+	return fe.addField(zap.Array(key, marshaler))
+}
+
+// AddObject ...
+func (fe *fieldEncoder) AddObject(key string, marshaler zapcore.ObjectMarshaler) error {
+	return fe.addField(zap.Object(key, marshaler))
+}
+
+// AddBinary ...
+func (fe *fieldEncoder) AddBinary(key string, value []byte) {
+	_ = fe.addField(zap.Binary(key, value))
+}
+
+// AddByteString ...
+func (fe *fieldEncoder) AddByteString(key string, value []byte) {
+	_ = fe.addField(zap.ByteString(key, value))
+}
+
+// AddBool ...
+func (fe *fieldEncoder) AddBool(key string, value bool) {
+	fe.fieldList = append(fe.fieldList, zap.Bool(key, value))
+}
+
+// AddComplex128 ...
+func (fe *fieldEncoder) AddComplex128(key string, value complex128) {
+	fe.fieldList = append(fe.fieldList, zap.Complex128(key, value))
+}
+
+// AddComplex64 ...
+func (fe *fieldEncoder) AddComplex64(key string, value complex64) {
+	fe.fieldList = append(fe.fieldList, zap.Complex64(key, value))
+}
+
+// AddDuration ...
+func (fe *fieldEncoder) AddDuration(key string, value time.Duration) {
+	fe.fieldList = append(fe.fieldList, zap.Duration(key, value))
+}
+
+// AddFloat64 ...
+func (fe *fieldEncoder) AddFloat64(key string, value float64) {
+	fe.fieldList = append(fe.fieldList, zap.Float64(key, value))
+}
+
+// AddFloat32 ...
+func (fe *fieldEncoder) AddFloat32(key string, value float32) {
+	fe.fieldList = append(fe.fieldList, zap.Float32(key, value))
+}
+
+// AddInt ...
+func (fe *fieldEncoder) AddInt(key string, value int) {
+	fe.fieldList = append(fe.fieldList, zap.Int(key, value))
+}
+
+// AddInt64 ...
+func (fe *fieldEncoder) AddInt64(key string, value int64) {
+	fe.fieldList = append(fe.fieldList, zap.Int64(key, value))
+}
+
+// AddInt32 ...
+func (fe *fieldEncoder) AddInt32(key string, value int32) {
+	fe.fieldList = append(fe.fieldList, zap.Int32(key, value))
+}
+
+// AddInt16 ...
+func (fe *fieldEncoder) AddInt16(key string, value int16) {
+	fe.fieldList = append(fe.fieldList, zap.Int16(key, value))
+}
+
+// AddInt8 ...
+func (fe *fieldEncoder) AddInt8(key string, value int8) {
+	fe.fieldList = append(fe.fieldList, zap.Int8(key, value))
+}
+
+// AddString ...
+func (fe *fieldEncoder) AddString(key, value string) {
+	_ = fe.addField(zap.String(key, value))
+}
+
+// AddTime ...
+func (fe *fieldEncoder) AddTime(key string, value time.Time) {
+	fe.fieldList = append(fe.fieldList, zap.Time(key, value))
+}
+
+// AddUint ...
+func (fe *fieldEncoder) AddUint(key string, value uint) {
+	fe.fieldList = append(fe.fieldList, zap.Uint(key, value))
+}
+
+// AddUint64 ...
+func (fe *fieldEncoder) AddUint64(key string, value uint64) {
+	fe.fieldList = append(fe.fieldList, zap.Uint64(key, value))
+}
+
+// AddUint32 ...
+func (fe *fieldEncoder) AddUint32(key string, value uint32) {
+	fe.fieldList = append(fe.fieldList, zap.Uint32(key, value))
+}
+
+// AddUint16 ...
+func (fe *fieldEncoder) AddUint16(key string, value uint16) {
+	fe.fieldList = append(fe.fieldList, zap.Uint16(key, value))
+}
+
+// AddUint8 ...
+func (fe *fieldEncoder) AddUint8(key string, value uint8) {
+	fe.fieldList = append(fe.fieldList, zap.Uint8(key, value))
+}
+
+// AddUintptr ...
+func (fe *fieldEncoder) AddUintptr(key string, value uintptr) {
+	fe.fieldList = append(fe.fieldList, zap.Uintptr(key, value))
+}
+
+// AddReflected ...
+func (fe *fieldEncoder) AddReflected(key string, value interface{}) error {
+	return fe.addField(zap.Reflect(key, value))
+}
+
+// OpenNamespace ...
+func (fe *fieldEncoder) OpenNamespace(key string) {
+	fe.fieldList = append(fe.fieldList, zap.Namespace(key))
+}
@@ -0,0 +1,243 @@
+// Package logger...
+//
+// Description : encoder_test 字段处理的单元测试
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 11:20 上午
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newTestLogger 生成写入内存的日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:21 上午 2026/10/19
+func newTestLogger(option ...SetLoggerOptionFunc) (*zap.Logger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	core := zapcore.NewCore(GetEncoder(option...), zapcore.AddSync(buf), zapcore.DebugLevel)
+	return zap.New(core), buf
+}
+
+// decodeTestLine 解析一行json日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:22 上午 2026/10/19
+func decodeTestLine(t *testing.T, line string) map[string]interface{} {
+	result := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(line), &result); nil != err {
+		t.Fatalf("日志不是合法的json : %s, err : %v", line, err)
+	}
+	return result
+}
+
+// Test_FieldLimit 测试字段长度、数组元素、嵌套深度的限制
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:24 上午 2026/10/19
+func Test_FieldLimit(t *testing.T) {
+	l, buf := newTestLogger(WithMaxStringLength(4), WithMaxArrayLength(2), WithMaxDepth(1))
+	l.With(zap.String("with", "abcdefgh")).Info("test",
+		zap.String("str", "abcdefgh"),
+		zap.Any("list", []int{1, 2, 3, 4}),
+		zap.Any("nest", map[string]interface{}{"a": map[string]interface{}{"b": 1}}),
+	)
+	data := decodeTestLine(t, buf.String())
+	if data["with"] != "abcd…(truncated 4 bytes)" || data["str"] != "abcd…(truncated 4 bytes)" {
+		t.Fatalf("字符串截断错误 : %s", buf.String())
+	}
+	if list := data["list"].([]interface{}); len(list) != 3 || list[2] != "…(truncated 5 bytes)" {
+		t.Fatalf("数组截断错误 : %s", buf.String())
+	}
+	if nest := data["nest"].(map[string]interface{}); !strings.HasPrefix(nest["a"].(string), "…(truncated") {
+		t.Fatalf("嵌套深度截断错误 : %s", buf.String())
+	}
+}
+
+// Test_FieldLimitKeepFormat 测试没有超出限制的复杂字段保持原有的输出格式
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:10 上午 2026/10/21
+func Test_FieldLimitKeepFormat(t *testing.T) {
+	l, buf := newTestLogger(WithMaxStringLength(64))
+	l.Info("test", zap.Object("obj", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+		enc.AddDuration("cost", time.Second)
+		enc.AddString("b", "short")
+		enc.AddString("a", "short")
+		return nil
+	})))
+	if !strings.Contains(buf.String(), `"obj":{"cost":1000,"b":"short","a":"short"}`) {
+		t.Fatalf("没有超出限制的字段格式发生变化 : %s", buf.String())
+	}
+}
+
+// Test_WithFieldSnapshot 测试With的字段在添加时序列化, 之后修改原始数据不影响日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:40 上午 2026/10/21
+func Test_WithFieldSnapshot(t *testing.T) {
+	l, buf := newTestLogger(WithMaxStringLength(64))
+	m := map[string]interface{}{"a": 1}
+	list := []string{"x"}
+	raw := []byte("raw")
+	l = l.With(zap.Any("m", m), zap.Strings("list", list), zap.ByteString("raw", raw), zap.Duration("cost", time.Second))
+	m["a"] = 2
+	list[0] = "y"
+	raw[0] = 'R'
+	l.Info("test")
+	if !strings.Contains(buf.String(), `"m":{"a":1},"list":["x"],"raw":"raw","cost":1000}`) {
+		t.Fatalf("With的字段受到了之后修改的影响 : %s", buf.String())
+	}
+}
+
+// Test_MaxEntryBytes 测试单条日志长度限制
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:28 上午 2026/10/19
+func Test_MaxEntryBytes(t *testing.T) {
+	l, buf := newTestLogger(WithMaxEntryBytes(256))
+	l.Info("test", zap.String("small", "ok"), zap.String("big", strings.Repeat("x", 1024)))
+	if buf.Len() > 256 {
+		t.Fatalf("日志长度超出限制 : %d", buf.Len())
+	}
+	data := decodeTestLine(t, buf.String())
+	if data["small"] != "ok" || nil != data["big"] || !strings.HasPrefix(data[defaultTruncatedKey].(string), "…(truncated") {
+		t.Fatalf("日志截断错误 : %s", buf.String())
+	}
+
+	// 转义之后长度变大的内容也不能超出限制
+	l, buf = newTestLogger(WithMaxEntryBytes(256))
+	l.Info(strings.Repeat("\x01", 100), zap.String("small", "ok"), zap.String("quote", strings.Repeat(`"`, 100)), zap.String("tail", "ok"))
+	if buf.Len() > 256 {
+		t.Fatalf("转义后的日志长度超出限制 : %d, %s", buf.Len(), buf.String())
+	}
+	data = decodeTestLine(t, buf.String())
+	if data["small"] != "ok" || data["tail"] != "ok" || nil != data["quote"] {
+		t.Fatalf("转义后的日志截断错误 : %s", buf.String())
+	}
+}
+
+// Test_StaticField 测试静态字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:40 下午 2026/10/19
+func Test_StaticField(t *testing.T) {
+	_ = os.Setenv("POD_NAME", "api-0")
+	defer func() { _ = os.Unsetenv("POD_NAME") }()
+	l, buf := newTestLogger(WithPidField(), WithServiceField("api", "v1.0.0"), WithKubernetesField())
+	l.Info("test", zap.String("a", "b"))
+	data := decodeTestLine(t, buf.String())
+	if data[defaultServiceKey] != "api" || data[defaultVersionKey] != "v1.0.0" || data["pod_name"] != "api-0" || nil == data[defaultPidKey] {
+		t.Fatalf("静态字段错误 : %s", buf.String())
+	}
+	if _, exist := data["pod_namespace"]; exist {
+		t.Fatalf("不存在的环境变量不应输出 : %s", buf.String())
+	}
+}
+
+// Test_Schema 测试日志格式预设
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:02 下午 2026/10/19
+func Test_Schema(t *testing.T) {
+	l, buf := newTestLogger(WithSchema(SchemaTypeOpenTelemetry), WithServiceField("api", "v1.0.0"))
+	l.Warn("test", zap.String("a", "b"))
+	data := decodeTestLine(t, buf.String())
+	if data["Body"] != "test" || data["SeverityText"] != "WARN" || data["SeverityNumber"] != float64(13) {
+		t.Fatalf("OpenTelemetry 格式错误 : %s", buf.String())
+	}
+	if data["Resource"].(map[string]interface{})["service.name"] != "api" || data["Attributes"].(map[string]interface{})["a"] != "b" {
+		t.Fatalf("OpenTelemetry 字段位置错误 : %s", buf.String())
+	}
+
+	l, buf = newTestLogger(WithSchema(SchemaTypeGELF))
+	l.Error("test", zap.String("id", "1"), zap.Int("code", 500))
+	data = decodeTestLine(t, buf.String())
+	if data["version"] != "1.1" || data["short_message"] != "test" || data["level"] != float64(3) {
+		t.Fatalf("GELF 格式错误 : %s", buf.String())
+	}
+	if data["__id"] != "1" || data["_code"] != float64(500) {
+		t.Fatalf("GELF 附加字段错误 : %s", buf.String())
+	}
+
+	l, buf = newTestLogger(WithSchema(SchemaTypeGELF))
+	l.Info("test", zap.Any("user", map[string]interface{}{"a": 1, "b": map[string]interface{}{"c": "d"}}), zap.Bool("ok", true), zap.Strings("tag list", []string{"x"}))
+	data = decodeTestLine(t, buf.String())
+	if data["_user.a"] != float64(1) || data["_user.b.c"] != "d" || data["_ok"] != "true" || data["_tag_list.0"] != "x" {
+		t.Fatalf("GELF 附加字段展开错误 : %s", buf.String())
+	}
+	for key, value := range data {
+		switch value.(type) {
+		case string, float64:
+		default:
+			t.Fatalf("GELF 字段 %s 的值不是字符串或数字 : %s", key, buf.String())
+		}
+	}
+
+	l, buf = newTestLogger(WithSchema(SchemaTypeECS))
+	l.Info("test")
+	data = decodeTestLine(t, buf.String())
+	if data["log.level"] != "info" || data["ecs.version"] != ecsVersion || nil == data["@timestamp"] {
+		t.Fatalf("ECS 格式错误 : %s", buf.String())
+	}
+}
+
+// Test_FieldNamespace 测试用户字段放入命名空间
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:24 下午 2026/10/19
+func Test_FieldNamespace(t *testing.T) {
+	l, buf := newTestLogger(WithFieldNamespace("data"), WithServiceField("api", "v1.0.0"))
+	l.With(zap.String("message", "with"), zap.Namespace("sub"), zap.Int("a", 1)).Info("test", zap.Int("b", 2))
+	data := decodeTestLine(t, buf.String())
+	if data["message"] != "test" || data[defaultServiceKey] != "api" {
+		t.Fatalf("保留字段与静态字段应在顶层 : %s", buf.String())
+	}
+	nested := data["data"].(map[string]interface{})
+	sub := nested["sub"].(map[string]interface{})
+	if nested["message"] != "with" || sub["a"] != float64(1) || sub["b"] != float64(2) {
+		t.Fatalf("用户字段未放入命名空间 : %s", buf.String())
+	}
+}
+
+// Test_DuplicateKeyPolicy 测试重复字段的处理策略
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:05 下午 2026/10/19
+func Test_DuplicateKeyPolicy(t *testing.T) {
+	testCaseList := []struct {
+		policy DuplicateKeyPolicy
+		expect string
+	}{
+		{DuplicateKeyPolicyLastWins, `"message":"test","message_1":"user","a":2}`},
+		{DuplicateKeyPolicyFirstWins, `"message":"test","a":1,"message_1":"user"}`},
+		{DuplicateKeyPolicyRename, `"message":"test","a":1,"message_1":"user","a_1":2}`},
+	}
+	for _, testCase := range testCaseList {
+		l, buf := newTestLogger(WithDuplicateKeyPolicy(testCase.policy), WithTimeKey(" "), WithCallerKey(" "))
+		l.With(zap.Int("a", 1)).Info("test", zap.String("message", "user"), zap.Int("a", 2))
+		if !strings.HasSuffix(strings.TrimSpace(buf.String()), testCase.expect) {
+			t.Fatalf("重复字段处理错误, 策略 : %v, 日志 : %s", testCase.policy, buf.String())
+		}
+	}
+}
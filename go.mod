@@ -1,16 +1,29 @@
 module github.com/go-developer/logger
 
-go 1.15
+go 1.21
 
 require (
-	github.com/fastly/go-utils v0.0.0-20160524210457-5317387f41f0 // indirect
 	github.com/gin-gonic/gin v1.6.3
+	github.com/lestrrat-go/file-rotatelogs v2.4.0+incompatible
+	github.com/pkg/errors v0.9.1
+	go.uber.org/zap v1.16.0
+)
+
+require (
+	github.com/gin-contrib/sse v0.1.0 // indirect
+	github.com/go-playground/locales v0.13.0 // indirect
+	github.com/go-playground/universal-translator v0.17.0 // indirect
+	github.com/go-playground/validator/v10 v10.2.0 // indirect
+	github.com/golang/protobuf v1.3.3 // indirect
 	github.com/jehiah/go-strftime v0.0.0-20171201141054-1d33003b3869 // indirect
 	github.com/jonboulle/clockwork v0.2.2 // indirect
-	github.com/lestrrat-go/file-rotatelogs v2.4.0+incompatible
+	github.com/leodido/go-urn v1.2.0 // indirect
 	github.com/lestrrat-go/strftime v1.0.3 // indirect
-	github.com/pkg/errors v0.9.1
+	github.com/mattn/go-isatty v0.0.12 // indirect
 	github.com/tebeka/strftime v0.1.5 // indirect
-	go.uber.org/zap v1.16.0
+	github.com/ugorji/go/codec v1.1.7 // indirect
+	go.uber.org/atomic v1.6.0 // indirect
+	go.uber.org/multierr v1.5.0 // indirect
+	golang.org/x/sys v0.0.0-20200116001909-b77594299b42 // indirect
 	gopkg.in/yaml.v2 v2.3.0 // indirect
 )
@@ -0,0 +1,182 @@
+// Package logger...
+//
+// Description : limit 日志字段长度、数组元素数量、嵌套深度的限制
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 10:40 上午
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// truncatedMarkerFormat 被截断内容的标记
+	truncatedMarkerFormat = "…(truncated %d bytes)"
+	// defaultTruncatedKey 日志整体被截断时, 记录截断信息的字段
+	defaultTruncatedKey = "truncated"
+	// truncatedReserveBytes 日志整体截断时, 为截断标记预留的长度
+	truncatedReserveBytes = 64
+)
+
+// truncatedMarker 生成截断标记
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:42 上午 2026/10/19
+func truncatedMarker(truncatedBytes int) string {
+	return fmt.Sprintf(truncatedMarkerFormat, truncatedBytes)
+}
+
+// truncateString 截断字符串到指定长度, 保证不会截断在一个utf8字符的中间
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:45 上午 2026/10/19
+func truncateString(str string, maxLength int) string {
+	if len(str) <= maxLength {
+		return str
+	}
+	if maxLength < 0 {
+		maxLength = 0
+	}
+	cut := maxLength
+	for cut > 0 && !utf8.RuneStart(str[cut]) {
+		cut--
+	}
+	return str[:cut] + truncatedMarker(len(str)-cut)
+}
+
+// hasValueLimit 是否配置了字段值的限制
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:47 上午 2026/10/19
+func (o *OptionLogger) hasValueLimit() bool {
+	return o.MaxStringLength > 0 || o.MaxArrayLength > 0 || o.MaxDepth > 0
+}
+
+// limitField 按配置限制字段的值
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:50 上午 2026/10/19
+func (o *OptionLogger) limitField(f zapcore.Field) zapcore.Field {
+	switch f.Type {
+	case zapcore.StringType:
+		if o.MaxStringLength > 0 {
+			f.String = truncateString(f.String, o.MaxStringLength)
+		}
+		return f
+	case zapcore.ByteStringType:
+		if o.MaxStringLength > 0 {
+			return zap.String(f.Key, truncateString(string(f.Interface.([]byte)), o.MaxStringLength))
+		}
+		return f
+	case zapcore.ArrayMarshalerType, zapcore.ObjectMarshalerType, zapcore.ReflectType:
+		value, ok := genericValue(f)
+		if !ok {
+			return f
+		}
+		// 没有超出限制的字段保持原样, 时间、耗时等字段的格式以及字段顺序不变
+		if value, ok = o.limitValue(value, 1); !ok {
+			return f
+		}
+		return zap.Reflect(f.Key, value)
+	default:
+		return f
+	}
+}
+
+// limitValue 递归限制数据, value 为 json 反序列化后的通用数据, 返回限制后的数据以及是否有内容被截断
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:56 上午 2026/10/19
+func (o *OptionLogger) limitValue(value interface{}, depth int) (interface{}, bool) {
+	switch val := value.(type) {
+	case string:
+		if o.MaxStringLength > 0 && len(val) > o.MaxStringLength {
+			return truncateString(val, o.MaxStringLength), true
+		}
+		return val, false
+	case []interface{}:
+		if o.MaxDepth > 0 && depth > o.MaxDepth {
+			return truncatedMarker(jsonSize(val)), true
+		}
+		var marker string
+		if o.MaxArrayLength > 0 && len(val) > o.MaxArrayLength {
+			marker = truncatedMarker(jsonSize(val[o.MaxArrayLength:]))
+			val = val[:o.MaxArrayLength]
+		}
+		truncated := len(marker) > 0
+		result := make([]interface{}, 0, len(val)+1)
+		for _, item := range val {
+			item, itemTruncated := o.limitValue(item, depth+1)
+			result = append(result, item)
+			truncated = truncated || itemTruncated
+		}
+		if len(marker) > 0 {
+			result = append(result, marker)
+		}
+		return result, truncated
+	case map[string]interface{}:
+		if o.MaxDepth > 0 && depth > o.MaxDepth {
+			return truncatedMarker(jsonSize(val)), true
+		}
+		truncated := false
+		result := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			item, itemTruncated := o.limitValue(item, depth+1)
+			result[k] = item
+			truncated = truncated || itemTruncated
+		}
+		return result, truncated
+	default:
+		return val, false
+	}
+}
+
+// genericValue 把复杂类型的字段转换为 json 反序列化后的通用数据
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:03 上午 2026/10/19
+func genericValue(f zapcore.Field) (interface{}, bool) {
+	var value interface{}
+	if f.Type == zapcore.ReflectType {
+		value = f.Interface
+	} else {
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+		value = enc.Fields[f.Key]
+	}
+	byteData, err := json.Marshal(value)
+	if nil != err {
+		return nil, false
+	}
+	var result interface{}
+	decoder := json.NewDecoder(bytes.NewReader(byteData))
+	decoder.UseNumber()
+	if err = decoder.Decode(&result); nil != err {
+		return nil, false
+	}
+	return result, true
+}
+
+// jsonSize 数据序列化为json之后的长度
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:05 上午 2026/10/19
+func jsonSize(value interface{}) int {
+	byteData, _ := json.Marshal(value)
+	return len(byteData)
+}
@@ -56,6 +56,15 @@ func NewLogger(loggerLevel zapcore.Level, consoleOutput bool, encoder zapcore.En
 	return log, nil
 }
 
+// NewRotateWriter 按日志切割的配置生成按时间切割的writer, 用于自定义日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:40 上午 2026/10/20
+func NewRotateWriter(splitConfig *RotateLogConfig) (io.Writer, error) {
+	return (&Logger{splitConfig: splitConfig}).getWriter()
+}
+
 type Logger struct {
 	splitConfig *RotateLogConfig
 	encoder     zapcore.Encoder
@@ -0,0 +1,142 @@
+// Package logger...
+//
+// Description : resource 每条日志都会携带的静态字段, 如 : 主机名、进程ID、服务名、版本
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 2:15 下午
+package logger
+
+import (
+	"os"
+	"path"
+	"runtime/debug"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultHostnameKey 主机名字段
+	defaultHostnameKey = "hostname"
+	// defaultPidKey 进程ID字段
+	defaultPidKey = "pid"
+	// defaultServiceKey 服务名字段
+	defaultServiceKey = "service"
+	// defaultVersionKey 服务版本字段
+	defaultVersionKey = "version"
+)
+
+// kubernetesEnvList kubernetes downward API 常用的环境变量, [环境变量名, 字段名]
+var kubernetesEnvList = [][2]string{
+	{"POD_NAME", "pod_name"},
+	{"POD_NAMESPACE", "pod_namespace"},
+	{"POD_IP", "pod_ip"},
+	{"NODE_NAME", "node_name"},
+}
+
+// WithStaticField 每条日志都会携带的静态字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:18 下午 2026/10/19
+func WithStaticField(fieldList ...zap.Field) SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		o.StaticFieldList = append(o.StaticFieldList, fieldList...)
+	}
+}
+
+// WithHostnameField 日志携带主机名
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:20 下午 2026/10/19
+func WithHostnameField() SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		hostname, err := os.Hostname()
+		if nil != err {
+			return
+		}
+		o.StaticFieldList = append(o.StaticFieldList, zap.String(defaultHostnameKey, hostname))
+	}
+}
+
+// WithPidField 日志携带进程ID
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:21 下午 2026/10/19
+func WithPidField() SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		o.StaticFieldList = append(o.StaticFieldList, zap.Int(defaultPidKey, os.Getpid()))
+	}
+}
+
+// WithServiceField 日志携带服务名与版本, 传空时尝试从编译信息中读取
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:23 下午 2026/10/19
+func WithServiceField(serviceName string, version string) SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		buildServiceName, buildVersion := getBuildInfo()
+		if serviceName = strings.Trim(serviceName, " "); len(serviceName) == 0 {
+			serviceName = buildServiceName
+		}
+		if version = strings.Trim(version, " "); len(version) == 0 {
+			version = buildVersion
+		}
+		if len(serviceName) > 0 {
+			o.StaticFieldList = append(o.StaticFieldList, zap.String(defaultServiceKey, serviceName))
+		}
+		if len(version) > 0 {
+			o.StaticFieldList = append(o.StaticFieldList, zap.String(defaultVersionKey, version))
+		}
+	}
+}
+
+// WithEnvField 读取环境变量作为静态字段, 环境变量不存在时忽略
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:26 下午 2026/10/19
+func WithEnvField(fieldKey string, envName string) SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		if value, exist := os.LookupEnv(envName); exist {
+			o.StaticFieldList = append(o.StaticFieldList, zap.String(fieldKey, value))
+		}
+	}
+}
+
+// WithKubernetesField 读取kubernetes downward API 注入的 POD_NAME / POD_NAMESPACE / POD_IP / NODE_NAME
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:28 下午 2026/10/19
+func WithKubernetesField() SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		for _, item := range kubernetesEnvList {
+			WithEnvField(item[1], item[0])(o)
+		}
+	}
+}
+
+// getBuildInfo 从编译信息中读取模块名与版本
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:31 下午 2026/10/19
+func getBuildInfo() (string, string) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", ""
+	}
+	version := info.Main.Version
+	if version == "(devel)" {
+		version = ""
+	}
+	if len(info.Main.Path) == 0 {
+		return "", version
+	}
+	return path.Base(info.Main.Path), version
+}
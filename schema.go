@@ -0,0 +1,378 @@
+// Package logger...
+//
+// Description : schema 常见日志平台的日志格式预设 : ECS / OpenTelemetry / GELF
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 3:05 下午
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SchemaType 日志格式预设类型
+type SchemaType uint
+
+const (
+	// SchemaTypeECS Elastic Common Schema
+	SchemaTypeECS = SchemaType(1)
+	// SchemaTypeOpenTelemetry OpenTelemetry 日志数据模型的json格式
+	SchemaTypeOpenTelemetry = SchemaType(2)
+	// SchemaTypeGELF Graylog Extended Log Format 1.1
+	SchemaTypeGELF = SchemaType(3)
+)
+
+const (
+	// ecsVersion 输出的ECS版本
+	ecsVersion = "1.6.0"
+	// gelfVersion 输出的GELF版本
+	gelfVersion = "1.1"
+)
+
+// WithSchema 使用日志格式预设, 会同时设置各个key、level的映射、时间格式以及用户字段的位置
+//
+// 需要在预设的基础上修改key时, 把 WithMessageKey 等选项放在 WithSchema 之后
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:08 下午 2026/10/19
+func WithSchema(schemaType SchemaType) SetLoggerOptionFunc {
+	return func(o *OptionLogger) {
+		switch schemaType {
+		case SchemaTypeECS:
+			setECSSchema(o)
+		case SchemaTypeOpenTelemetry:
+			setOpenTelemetrySchema(o)
+		case SchemaTypeGELF:
+			setGELFSchema(o)
+		}
+	}
+}
+
+// setECSSchema Elastic Common Schema, 用户字段放在顶层
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:12 下午 2026/10/19
+func setECSSchema(o *OptionLogger) {
+	o.UseJsonFormat = true
+	o.MessageKey = "message"
+	o.LevelKey = "log.level"
+	o.TimeKey = "@timestamp"
+	o.CallerKey = ""
+	o.NameKey = "log.logger"
+	o.StacktraceKey = "error.stack_trace"
+	o.LevelEncoder = zapcore.LowercaseLevelEncoder
+	o.TimeEncoder = ISO8601MsTimeEncoder
+	o.FormatStaticFieldKey = mapFieldKey(map[string]string{
+		defaultHostnameKey: "host.hostname",
+		defaultPidKey:      "process.pid",
+		defaultServiceKey:  "service.name",
+		defaultVersionKey:  "service.version",
+	})
+	o.EntryFieldFunc = func(ent zapcore.Entry) ([]zapcore.Field, []zapcore.Field) {
+		topFieldList := []zapcore.Field{zap.String("ecs.version", ecsVersion)}
+		if ent.Caller.Defined {
+			topFieldList = append(topFieldList,
+				zap.String("log.origin.file.name", o.callerFile(ent.Caller)),
+				zap.Int("log.origin.file.line", ent.Caller.Line),
+				zap.String("log.origin.function", ent.Caller.Function),
+			)
+		}
+		return topFieldList, nil
+	}
+}
+
+// setOpenTelemetrySchema OpenTelemetry 日志数据模型, 静态字段放在 Resource 中, 用户字段放在 Attributes 中
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:18 下午 2026/10/19
+func setOpenTelemetrySchema(o *OptionLogger) {
+	o.UseJsonFormat = true
+	o.MessageKey = "Body"
+	o.LevelKey = "SeverityText"
+	o.TimeKey = "Timestamp"
+	o.CallerKey = ""
+	o.NameKey = ""
+	o.StacktraceKey = ""
+	o.LevelEncoder = zapcore.CapitalLevelEncoder
+	o.TimeEncoder = NanoTimestampEncoder
+	o.StaticFieldNamespace = "Resource"
+	o.FieldNamespace = "Attributes"
+	o.FormatStaticFieldKey = mapFieldKey(map[string]string{
+		defaultHostnameKey: "host.name",
+		defaultPidKey:      "process.pid",
+		defaultServiceKey:  "service.name",
+		defaultVersionKey:  "service.version",
+	})
+	o.EntryFieldFunc = func(ent zapcore.Entry) ([]zapcore.Field, []zapcore.Field) {
+		topFieldList := []zapcore.Field{zap.Int("SeverityNumber", otelSeverityNumber(ent.Level))}
+		attributeList := make([]zapcore.Field, 0, 5)
+		if ent.Caller.Defined {
+			attributeList = append(attributeList,
+				zap.String("code.filepath", o.callerFile(ent.Caller)),
+				zap.Int("code.lineno", ent.Caller.Line),
+				zap.String("code.function", ent.Caller.Function),
+			)
+		}
+		if len(ent.LoggerName) > 0 {
+			attributeList = append(attributeList, zap.String("logger.name", ent.LoggerName))
+		}
+		if len(ent.Stack) > 0 {
+			attributeList = append(attributeList, zap.String("exception.stacktrace", ent.Stack))
+		}
+		return topFieldList, attributeList
+	}
+}
+
+// setGELFSchema GELF 1.1, 静态字段与用户字段都作为 "_" 开头的附加字段, 嵌套的字段展开为 "." 连接的key
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:25 下午 2026/10/19
+func setGELFSchema(o *OptionLogger) {
+	host, _ := os.Hostname()
+	o.UseJsonFormat = true
+	o.MessageKey = "short_message"
+	o.LevelKey = "level"
+	o.TimeKey = "timestamp"
+	o.CallerKey = ""
+	o.NameKey = ""
+	o.StacktraceKey = "full_message"
+	o.LevelEncoder = gelfLevelEncoder
+	o.TimeEncoder = SecondTimestampEncoder
+	o.FormatStaticFieldKey = gelfFieldKey
+	o.FormatFieldKey = gelfFieldKey
+	o.FormatFieldList = gelfFieldList
+	o.EntryFieldFunc = func(ent zapcore.Entry) ([]zapcore.Field, []zapcore.Field) {
+		topFieldList := []zapcore.Field{zap.String("version", gelfVersion), zap.String("host", host)}
+		additionalList := make([]zapcore.Field, 0, 3)
+		if ent.Caller.Defined {
+			additionalList = append(additionalList,
+				zap.String("file", o.callerFile(ent.Caller)),
+				zap.Int("line", ent.Caller.Line),
+			)
+		}
+		if len(ent.LoggerName) > 0 {
+			additionalList = append(additionalList, zap.String("logger", ent.LoggerName))
+		}
+		return topFieldList, additionalList
+	}
+}
+
+// ISO8601MsTimeEncoder UTC 毫秒精度的 ISO8601 时间
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:30 下午 2026/10/19
+func ISO8601MsTimeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+	enc.AppendString(t.UTC().Format("2006-01-02T15:04:05.000Z07:00"))
+}
+
+// NanoTimestampEncoder 纳秒时间戳
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:31 下午 2026/10/19
+func NanoTimestampEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+	enc.AppendInt64(t.UnixNano())
+}
+
+// SecondTimestampEncoder 带小数的秒级时间戳
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:32 下午 2026/10/19
+func SecondTimestampEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+	enc.AppendFloat64(float64(t.UnixNano()/1e6) / 1e3)
+}
+
+// otelSeverityNumber zap 的日志级别转换为 OpenTelemetry 的 SeverityNumber
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:34 下午 2026/10/19
+func otelSeverityNumber(level zapcore.Level) int {
+	switch level {
+	case zapcore.DebugLevel:
+		return 5
+	case zapcore.InfoLevel:
+		return 9
+	case zapcore.WarnLevel:
+		return 13
+	case zapcore.ErrorLevel:
+		return 17
+	case zapcore.DPanicLevel:
+		return 18
+	case zapcore.PanicLevel:
+		return 21
+	default:
+		return 22
+	}
+}
+
+// gelfLevelEncoder zap 的日志级别转换为 syslog 的级别
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:36 下午 2026/10/19
+func gelfLevelEncoder(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	switch level {
+	case zapcore.DebugLevel:
+		enc.AppendInt(7)
+	case zapcore.InfoLevel:
+		enc.AppendInt(6)
+	case zapcore.WarnLevel:
+		enc.AppendInt(4)
+	case zapcore.ErrorLevel:
+		enc.AppendInt(3)
+	case zapcore.DPanicLevel, zapcore.PanicLevel:
+		enc.AppendInt(2)
+	default:
+		enc.AppendInt(0)
+	}
+}
+
+// gelfFieldKey GELF 的附加字段必须以 "_" 开头, 只允许 [\w.\-] 字符, 且不允许使用 "_id"
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:38 下午 2026/10/19
+func gelfFieldKey(key string) string {
+	key = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, key)
+	if key == "id" {
+		return "__id"
+	}
+	return "_" + key
+}
+
+// gelfFieldList GELF 附加字段的值只能是字符串或数字, 对象与数组展开为 "." 连接的key, 其他类型的值转换为字符串
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:30 上午 2026/10/21
+func gelfFieldList(fieldList []zapcore.Field) []zapcore.Field {
+	result := make([]zapcore.Field, 0, len(fieldList))
+	prefix := ""
+	for _, f := range fieldList {
+		if f.Type == zapcore.NamespaceType {
+			prefix += f.Key + "."
+			continue
+		}
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+		keyList := make([]string, 0, len(enc.Fields))
+		for key := range enc.Fields {
+			keyList = append(keyList, key)
+		}
+		// error 等类型会生成多个key, 排序保证输出顺序固定
+		sort.Strings(keyList)
+		for _, key := range keyList {
+			result = appendGELFValue(result, prefix+key, enc.Fields[key])
+		}
+	}
+	return result
+}
+
+// appendGELFValue 递归展开字段的值, 空值忽略
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:36 上午 2026/10/21
+func appendGELFValue(fieldList []zapcore.Field, key string, value interface{}) []zapcore.Field {
+	switch val := value.(type) {
+	case nil:
+		return fieldList
+	case string:
+		return append(fieldList, zap.String(key, val))
+	case bool:
+		return append(fieldList, zap.String(key, strconv.FormatBool(val)))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64:
+		return append(fieldList, zap.Any(key, val))
+	case time.Time:
+		return append(fieldList, zap.Time(key, val))
+	case time.Duration:
+		return append(fieldList, zap.Duration(key, val))
+	case json.Number:
+		if intValue, err := val.Int64(); nil == err {
+			return append(fieldList, zap.Int64(key, intValue))
+		}
+		floatValue, _ := val.Float64()
+		return append(fieldList, zap.Float64(key, floatValue))
+	case map[string]interface{}:
+		keyList := make([]string, 0, len(val))
+		for itemKey := range val {
+			keyList = append(keyList, itemKey)
+		}
+		sort.Strings(keyList)
+		for _, itemKey := range keyList {
+			fieldList = appendGELFValue(fieldList, key+"."+itemKey, val[itemKey])
+		}
+		return fieldList
+	case []interface{}:
+		for idx, item := range val {
+			fieldList = appendGELFValue(fieldList, key+"."+strconv.Itoa(idx), item)
+		}
+		return fieldList
+	default:
+		// 反射类型的数据先转换为 json 反序列化后的通用数据, 无法转换的使用字符串
+		byteData, err := json.Marshal(val)
+		if nil != err {
+			return append(fieldList, zap.String(key, fmt.Sprint(val)))
+		}
+		var generic interface{}
+		decoder := json.NewDecoder(bytes.NewReader(byteData))
+		decoder.UseNumber()
+		if err = decoder.Decode(&generic); nil != err {
+			return append(fieldList, zap.String(key, string(byteData)))
+		}
+		return appendGELFValue(fieldList, key, generic)
+	}
+}
+
+// mapFieldKey 按映射表转换字段key, 不在映射表中的保持不变
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:40 下午 2026/10/19
+func mapFieldKey(keyTable map[string]string) func(key string) string {
+	return func(key string) string {
+		if newKey, exist := keyTable[key]; exist {
+			return newKey
+		}
+		return key
+	}
+}
+
+// callerFile 日志调用的文件, 按配置使用短路径或完整路径
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:42 下午 2026/10/19
+func (o *OptionLogger) callerFile(caller zapcore.EntryCaller) string {
+	if !o.UseShortCaller {
+		return caller.File
+	}
+	trimmedPath := caller.TrimmedPath()
+	if idx := strings.LastIndex(trimmedPath, ":"); idx >= 0 {
+		return trimmedPath[:idx]
+	}
+	return trimmedPath
+}
@@ -0,0 +1,215 @@
+// Package logger...
+//
+// Description : slog 基于zap core实现的 slog.Handler, slog 与 zap 的日志输出格式完全一致
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 6:10 上午
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewSlogHandler 生成写入zap日志实例的 slog.Handler, 使用日志实例的core, 日志文件、切割方式、字段名与zap一致
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:12 上午 2026/10/20
+func NewSlogHandler(l *zap.Logger) slog.Handler {
+	return &slogHandler{
+		core:      l.Core(),
+		groupList: make([]string, 0),
+	}
+}
+
+// slogHandler 基于zap core实现的 slog.Handler
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:14 上午 2026/10/20
+type slogHandler struct {
+	core      zapcore.Core // 已经设置了 WithAttrs 字段的core
+	groupList []string     // 还没有字段的分组, 有字段时才生成, 没有字段的分组不输出
+}
+
+// Enabled ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:15 上午 2026/10/20
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.core.Enabled(slogLevel(level))
+}
+
+// Handle ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:17 上午 2026/10/20
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	ent := zapcore.Entry{
+		Level:   slogLevel(record.Level),
+		Time:    record.Time,
+		Message: record.Message,
+	}
+	if ent.Time.IsZero() {
+		ent.Time = time.Now()
+	}
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		ent.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+	}
+	ce := h.core.Check(ent, nil)
+	if nil == ce {
+		return nil
+	}
+	fieldList := make([]zapcore.Field, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		fieldList = appendSlogAttr(fieldList, attr)
+		return true
+	})
+	if len(fieldList) > 0 {
+		fieldList = append(h.namespaceFieldList(), fieldList...)
+	}
+	ce.Write(fieldList...)
+	return nil
+}
+
+// WithAttrs ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:20 上午 2026/10/20
+func (h *slogHandler) WithAttrs(attrList []slog.Attr) slog.Handler {
+	fieldList := make([]zapcore.Field, 0, len(attrList))
+	for _, attr := range attrList {
+		fieldList = appendSlogAttr(fieldList, attr)
+	}
+	if len(fieldList) == 0 {
+		return h
+	}
+	return &slogHandler{
+		core:      h.core.With(append(h.namespaceFieldList(), fieldList...)),
+		groupList: make([]string, 0),
+	}
+}
+
+// WithGroup ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:22 上午 2026/10/20
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if len(name) == 0 {
+		return h
+	}
+	groupList := make([]string, 0, len(h.groupList)+1)
+	return &slogHandler{
+		core:      h.core,
+		groupList: append(append(groupList, h.groupList...), name),
+	}
+}
+
+// namespaceFieldList 还没有字段的分组转换为zap的Namespace
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:24 上午 2026/10/20
+func (h *slogHandler) namespaceFieldList() []zapcore.Field {
+	fieldList := make([]zapcore.Field, 0, len(h.groupList))
+	for _, group := range h.groupList {
+		fieldList = append(fieldList, zap.Namespace(group))
+	}
+	return fieldList
+}
+
+// slogLevel slog 的日志级别转换为zap的日志级别, 自定义的级别向下取最近的级别
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:26 上午 2026/10/20
+func slogLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return zapcore.DebugLevel
+	case level < slog.LevelWarn:
+		return zapcore.InfoLevel
+	case level < slog.LevelError:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+// appendSlogAttr slog 的属性转换为zap的字段, 空属性忽略, key为空的分组展开到上一级
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:29 上午 2026/10/20
+func appendSlogAttr(fieldList []zapcore.Field, attr slog.Attr) []zapcore.Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fieldList
+	}
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		groupAttrList := attr.Value.Group()
+		if len(groupAttrList) == 0 {
+			return fieldList
+		}
+		if len(attr.Key) == 0 {
+			for _, groupAttr := range groupAttrList {
+				fieldList = appendSlogAttr(fieldList, groupAttr)
+			}
+			return fieldList
+		}
+		return append(fieldList, zap.Object(attr.Key, slogGroupMarshaler(groupAttrList)))
+	case slog.KindString:
+		return append(fieldList, zap.String(attr.Key, attr.Value.String()))
+	case slog.KindInt64:
+		return append(fieldList, zap.Int64(attr.Key, attr.Value.Int64()))
+	case slog.KindUint64:
+		return append(fieldList, zap.Uint64(attr.Key, attr.Value.Uint64()))
+	case slog.KindFloat64:
+		return append(fieldList, zap.Float64(attr.Key, attr.Value.Float64()))
+	case slog.KindBool:
+		return append(fieldList, zap.Bool(attr.Key, attr.Value.Bool()))
+	case slog.KindDuration:
+		return append(fieldList, zap.Duration(attr.Key, attr.Value.Duration()))
+	case slog.KindTime:
+		return append(fieldList, zap.Time(attr.Key, attr.Value.Time()))
+	default:
+		if err, ok := attr.Value.Any().(error); ok {
+			return append(fieldList, zap.NamedError(attr.Key, err))
+		}
+		return append(fieldList, zap.Any(attr.Key, attr.Value.Any()))
+	}
+}
+
+// slogGroupMarshaler slog 的分组属性
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:33 上午 2026/10/20
+type slogGroupMarshaler []slog.Attr
+
+// MarshalLogObject ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:34 上午 2026/10/20
+func (sgm slogGroupMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, attr := range sgm {
+		for _, f := range appendSlogAttr(nil, attr) {
+			f.AddTo(enc)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Package logger...
+//
+// Description : slog_test slog.Handler 的单元测试
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 6:40 上午
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Test_SlogHandler 测试 slog 与 zap 的日志输出一致
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:40 上午 2026/10/20
+func Test_SlogHandler(t *testing.T) {
+	l, buf := newTestLogger(WithMessageKey("msg"))
+	sl := slog.New(NewSlogHandler(l))
+	_, _, callLine, _ := runtime.Caller(0)
+	sl.With("uid", 10).WithGroup("req").WithGroup("empty").Warn("slog", "path", "/", slog.Group("user", "name", "zhang"), slog.Group("", "inline", true))
+	sl.WithGroup("unused").Debug("debug", "err", errors.New("fail"), "cost", time.Second)
+	l.With(zap.Int("uid", 10)).Warn("slog", zap.Namespace("req"), zap.Namespace("empty"), zap.String("path", "/"),
+		zap.Object("user", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("name", "zhang")
+			return nil
+		})), zap.Bool("inline", true))
+
+	lineList := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	slogData, debugData, zapData := decodeTestLine(t, lineList[0]), decodeTestLine(t, lineList[1]), decodeTestLine(t, lineList[2])
+	if !strings.HasSuffix(slogData["file"].(string), fmt.Sprintf("slog_test.go:%d", callLine+1)) {
+		t.Fatalf("slog 调用文件错误 : %s", lineList[0])
+	}
+	for _, key := range []string{"time", "file"} {
+		delete(slogData, key)
+		delete(zapData, key)
+	}
+	if FormatJson(slogData) != FormatJson(zapData) {
+		t.Fatalf("slog 与 zap 输出不一致 : %s", buf.String())
+	}
+	if debugData["level"] != "DEBUG" || debugData["unused"].(map[string]interface{})["err"] != "fail" {
+		t.Fatalf("slog 日志级别或属性转换错误 : %s", lineList[1])
+	}
+}
@@ -0,0 +1,253 @@
+// Package logger...
+//
+// Description : trace 解析 W3C traceparent 以及 b3 链路追踪header, 日志记录 trace_id、span_id、sampled, 只依赖标准库
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 5:00 上午
+package logger
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// TraceparentHeader W3C 链路追踪header
+	TraceparentHeader = "traceparent"
+	// B3Header b3 单header格式
+	B3Header = "b3"
+	// defaultTraceIDKey 默认的 trace id 字段名
+	defaultTraceIDKey = "trace_id"
+	// defaultSpanIDKey 默认的 span id 字段名
+	defaultSpanIDKey = "span_id"
+	// defaultSampledKey 默认的是否采样字段名
+	defaultSampledKey = "sampled"
+)
+
+// traceContextKey 链路追踪信息在 context.Context 中的key
+type traceContextKey struct{}
+
+// TraceContext 链路追踪信息
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:03 上午 2026/10/20
+type TraceContext struct {
+	TraceID string // 32位16进制的 trace id, b3 的16位 trace id 左侧补0
+	SpanID  string // 16位16进制的 span id
+	Sampled bool   // 是否采样
+}
+
+// TraceOption 链路追踪的配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:05 上午 2026/10/20
+type TraceOption struct {
+	TraceIDKey string // trace id 的字段名
+	SpanIDKey  string // span id 的字段名
+	SampledKey string // 是否采样的字段名
+	EnableB3   bool   // 没有 traceparent 时是否解析 b3 header
+}
+
+// SetTraceOptionFunc 设置链路追踪的配置
+type SetTraceOptionFunc func(o *TraceOption)
+
+// WithTraceFieldKey 设置链路追踪的字段名, 为空的字段名使用默认值
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:07 上午 2026/10/20
+func WithTraceFieldKey(traceIDKey string, spanIDKey string, sampledKey string) SetTraceOptionFunc {
+	return func(o *TraceOption) {
+		if traceIDKey = strings.TrimSpace(traceIDKey); len(traceIDKey) > 0 {
+			o.TraceIDKey = traceIDKey
+		}
+		if spanIDKey = strings.TrimSpace(spanIDKey); len(spanIDKey) > 0 {
+			o.SpanIDKey = spanIDKey
+		}
+		if sampledKey = strings.TrimSpace(sampledKey); len(sampledKey) > 0 {
+			o.SampledKey = sampledKey
+		}
+	}
+}
+
+// WithB3Trace 没有 traceparent 时解析 b3 header, 支持单header与多header格式
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:08 上午 2026/10/20
+func WithB3Trace() SetTraceOptionFunc {
+	return func(o *TraceOption) {
+		o.EnableB3 = true
+	}
+}
+
+// NewTraceOption 生成链路追踪的配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:10 上午 2026/10/20
+func NewTraceOption(option ...SetTraceOptionFunc) *TraceOption {
+	o := &TraceOption{
+		TraceIDKey: defaultTraceIDKey,
+		SpanIDKey:  defaultSpanIDKey,
+		SampledKey: defaultSampledKey,
+	}
+	for _, f := range option {
+		f(o)
+	}
+	return o
+}
+
+// Extract 从请求header中解析链路追踪信息
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:12 上午 2026/10/20
+func (o *TraceOption) Extract(header http.Header) (TraceContext, bool) {
+	if tc, ok := ParseTraceparent(header.Get(TraceparentHeader)); ok {
+		return tc, true
+	}
+	if o.EnableB3 {
+		return ParseB3(header)
+	}
+	return TraceContext{}, false
+}
+
+// FieldList 链路追踪信息对应的日志字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:14 上午 2026/10/20
+func (o *TraceOption) FieldList(tc TraceContext) []zap.Field {
+	return []zap.Field{
+		zap.String(o.TraceIDKey, tc.TraceID),
+		zap.String(o.SpanIDKey, tc.SpanID),
+		zap.Bool(o.SampledKey, tc.Sampled),
+	}
+}
+
+// ParseTraceparent 解析 W3C traceparent, 格式 : version-trace_id-span_id-flags
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:16 上午 2026/10/20
+func ParseTraceparent(traceparent string) (TraceContext, bool) {
+	partList := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(partList) < 4 || !isHexID(partList[0], 2) || partList[0] == "ff" {
+		return TraceContext{}, false
+	}
+	// 版本 00 只允许4部分, 更高的版本按规范忽略多余的部分
+	if partList[0] == "00" && len(partList) != 4 {
+		return TraceContext{}, false
+	}
+	traceID, spanID, flags := partList[1], partList[2], partList[3]
+	if !isHexID(traceID, 32) || !isHexID(spanID, 16) || !isHexID(flags, 2) {
+		return TraceContext{}, false
+	}
+	flagByte, _ := hex.DecodeString(flags)
+	return TraceContext{TraceID: traceID, SpanID: spanID, Sampled: flagByte[0]&0x01 == 0x01}, true
+}
+
+// ParseB3 解析 b3 header, 优先使用单header格式 : trace_id-span_id-sampled-parent_span_id
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:20 上午 2026/10/20
+func ParseB3(header http.Header) (TraceContext, bool) {
+	var traceID, spanID, sampled string
+	if b3 := strings.TrimSpace(header.Get(B3Header)); len(b3) > 0 {
+		partList := strings.Split(b3, "-")
+		if len(partList) < 2 {
+			return TraceContext{}, false
+		}
+		traceID, spanID = partList[0], partList[1]
+		if len(partList) > 2 {
+			sampled = partList[2]
+		}
+	} else {
+		traceID, spanID, sampled = header.Get("X-B3-TraceId"), header.Get("X-B3-SpanId"), header.Get("X-B3-Sampled")
+		if header.Get("X-B3-Flags") == "1" {
+			sampled = "d"
+		}
+	}
+	traceID, spanID = strings.ToLower(strings.TrimSpace(traceID)), strings.ToLower(strings.TrimSpace(spanID))
+	if isHexID(traceID, 16) {
+		traceID = strings.Repeat("0", 16) + traceID
+	}
+	if !isHexID(traceID, 32) || !isHexID(spanID, 16) {
+		return TraceContext{}, false
+	}
+	sampled = strings.ToLower(strings.TrimSpace(sampled))
+	return TraceContext{TraceID: traceID, SpanID: spanID, Sampled: sampled == "1" || sampled == "d" || sampled == "true"}, true
+}
+
+// isHexID 是否指定长度的小写16进制, 并且不全是0
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:24 上午 2026/10/20
+func isHexID(id string, length int) bool {
+	if len(id) != length {
+		return false
+	}
+	allZero := true
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+		allZero = allZero && c == '0'
+	}
+	// 版本与flags允许为0
+	return !allZero || length == 2
+}
+
+// NewTraceContext 生成携带链路追踪信息的 context.Context
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:27 上午 2026/10/20
+func NewTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	if nil == ctx {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceFromContext 获取 context.Context 中的链路追踪信息
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:28 上午 2026/10/20
+func TraceFromContext(ctx context.Context) (TraceContext, bool) {
+	if nil == ctx {
+		return TraceContext{}, false
+	}
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok
+}
+
+// TraceHandler net/http 链路追踪中间件, 解析请求header中的链路追踪信息, 记录在请求的 context.Context 中
+//
+// 通过 Ctx 获取的日志实例会携带链路追踪字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:31 上午 2026/10/20
+func TraceHandler(next http.Handler, option ...SetTraceOptionFunc) http.Handler {
+	o := NewTraceOption(option...)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tc, ok := o.Extract(r.Header); ok {
+			ctx := WithContext(NewTraceContext(r.Context(), tc), o.FieldList(tc)...)
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,48 @@
+// Package logger...
+//
+// Description : trace_test 链路追踪解析的单元测试
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 5:50 上午
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test_Trace 测试解析链路追踪header
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:50 上午 2026/10/20
+func Test_Trace(t *testing.T) {
+	if tc, ok := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"); !ok || tc.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || tc.SpanID != "00f067aa0ba902b7" || !tc.Sampled {
+		t.Fatalf("traceparent 解析错误 : %v", tc)
+	}
+	for _, traceparent := range []string{"", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"} {
+		if _, ok := ParseTraceparent(traceparent); ok {
+			t.Fatalf("非法的 traceparent 解析成功 : %s", traceparent)
+		}
+	}
+	header := http.Header{}
+	header.Set("X-B3-TraceId", "a3ce929d0e0e4736")
+	header.Set("X-B3-SpanId", "00f067aa0ba902b7")
+	header.Set("X-B3-Sampled", "0")
+	if tc, ok := ParseB3(header); !ok || tc.TraceID != "0000000000000000a3ce929d0e0e4736" || tc.Sampled {
+		t.Fatalf("b3 解析错误 : %v", tc)
+	}
+
+	l, buf := newTestLogger()
+	handler := TraceHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Ctx(NewContext(r.Context(), l)).Info("handler")
+	}), WithB3Trace(), WithTraceFieldKey("trace.id", "", ""))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(B3Header, "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if data := decodeTestLine(t, buf.String()); data["trace.id"] != "80f198ee56343ba864fe8b2a57d3eff7" || data["span_id"] != "e457b5a2e4d86bd1" || data["sampled"] != true {
+		t.Fatalf("链路追踪字段错误 : %s", buf.String())
+	}
+}
@@ -0,0 +1,405 @@
+// Package wrapper...
+//
+// Description : gin_access_log 使用gin框架时, 每个请求记录一条访问日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 5:20 下午
+package wrapper
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// defaultAccessLogMessage 访问日志默认的message
+	defaultAccessLogMessage = "http access"
+)
+
+// AccessLogOption 访问日志的配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:22 下午 2026/10/19
+type AccessLogOption struct {
+	Message          string                   // 访问日志的message
+	SlowThreshold    time.Duration            // 慢请求的阈值, 超过阈值使用Warn级别记录, 0 - 不区分慢请求
+	TrustedProxyList []string                 // 可信代理的IP或者CIDR, 只有来自可信代理的请求才会读取 X-Forwarded-For / X-Real-Ip
+	CaptureHeader    bool                     // 是否记录请求与响应的header
+	CaptureBody      bool                     // 是否记录请求与响应的body
+	MaxBodyBytes     int                      // 记录body的最大长度
+	ContentTypeList  []string                 // 记录body的content-type, 按前缀匹配
+	HeaderAllowList  []string                 // 只记录的header, 为空记录禁止列表之外的全部header
+	HeaderDenyList   []string                 // 不记录的header
+	RedactKeyList    []string                 // 需要脱敏的header、json字段、表单字段
+	RedactFunc       func(body string) string // 自定义body脱敏方法
+	RoutePolicyList  []RoutePolicy            // 路由级别的日志策略
+	CanonicalLog     bool                     // 合并模式, 请求内的 Debug / Info 日志合并到访问日志中
+	TailBufferSize   int                      // 请求内缓存的 Debug / Info 日志数量, 请求失败时才输出, 0 - 不缓存
+	TextLogFormat    TextLogFormat            // 文本访问日志的格式
+	TextLogWriter    io.Writer                // 文本访问日志的writer, 设置之后访问日志使用文本格式记录
+	TextLogFieldList []TextLogField           // 文本访问日志末尾追加的字段
+	trustedNetList   []*net.IPNet             // 解析后的可信代理
+	headerAllowTable map[string]bool          // 解析后的header允许列表
+	headerDenyTable  map[string]bool          // 解析后的header禁止列表
+	redactKeyTable   map[string]bool          // 解析后的脱敏key
+	textLogFileErr   error                    // 文本访问日志文件创建失败的错误
+}
+
+// SetAccessLogOptionFunc 设置访问日志的配置
+type SetAccessLogOptionFunc func(o *AccessLogOption)
+
+// WithAccessLogMessage 设置访问日志的message
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:24 下午 2026/10/19
+func WithAccessLogMessage(message string) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		message = strings.Trim(message, " ")
+		if len(message) == 0 {
+			return
+		}
+		o.Message = message
+	}
+}
+
+// WithSlowThreshold 设置慢请求的阈值
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:25 下午 2026/10/19
+func WithSlowThreshold(threshold time.Duration) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		if threshold <= 0 {
+			return
+		}
+		o.SlowThreshold = threshold
+	}
+}
+
+// WithTrustedProxyList 设置可信代理列表, 支持IP与CIDR
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:26 下午 2026/10/19
+func WithTrustedProxyList(proxyList ...string) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		o.TrustedProxyList = append(o.TrustedProxyList, proxyList...)
+	}
+}
+
+// newAccessLogOption 生成访问日志配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:28 下午 2026/10/19
+func newAccessLogOption(option ...SetAccessLogOptionFunc) *AccessLogOption {
+	o := &AccessLogOption{
+		Message:          defaultAccessLogMessage,
+		TrustedProxyList: make([]string, 0),
+		MaxBodyBytes:     defaultMaxBodyBytes,
+		ContentTypeList:  defaultCaptureContentTypeList,
+	}
+	for _, f := range option {
+		f(o)
+	}
+	o.trustedNetList = parseTrustedProxyList(o.TrustedProxyList)
+	o.initCaptureOption()
+	return o
+}
+
+// AccessLogMiddleware 访问日志中间件, 每个请求结束时记录一条日志
+//
+// 5xx 的请求使用Error级别, 超过慢请求阈值的请求使用Warn级别, 其余使用Info级别, ctx.Error 设置的错误按错误类型提升级别
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:30 下午 2026/10/19
+func (gw *GinWrapper) AccessLogMiddleware(option ...SetAccessLogOptionFunc) gin.HandlerFunc {
+	o := newAccessLogOption(option...)
+	for _, err := range o.initRoutePolicyList() {
+		gw.loggerInstance.Error("路由日志策略配置错误, 策略不生效", zap.Error(err))
+	}
+	if nil != o.textLogFileErr {
+		gw.loggerInstance.Error("文本访问日志文件创建失败, 使用json格式记录访问日志", zap.Error(o.textLogFileErr))
+	}
+	return func(ctx *gin.Context) {
+		state := &accessLogState{start: time.Now(), body: &countReader{ReadCloser: ctx.Request.Body}}
+		if nil != ctx.Request.Body {
+			ctx.Request.Body = state.body
+		}
+		state.policy = o.matchRoutePolicy(ctx)
+		state.writer = o.startCapture(ctx, state.policy)
+		state.cl = newCanonicalLog(ctx, o.CanonicalLog)
+		if o.TailBufferSize > 0 {
+			state.tb = newTailBuffer(ctx, o.TailBufferSize)
+		}
+
+		// 请求中 panic 时同样输出缓存的日志、记录访问日志, 之后继续 panic 交给外层的 recovery 处理
+		finished := false
+		defer func() {
+			if finished {
+				return
+			}
+			recovered := recover()
+			status := ctx.Writer.Status()
+			if !ctx.Writer.Written() {
+				status = http.StatusInternalServerError
+			}
+			gw.writeAccessLog(ctx, o, state, status)
+			// runtime.Goexit 时没有需要继续的 panic
+			if nil != recovered {
+				panic(recovered)
+			}
+		}()
+
+		ctx.Next()
+
+		finished = true
+		gw.writeAccessLog(ctx, o, state, ctx.Writer.Status())
+	}
+}
+
+// accessLogState 请求开始时生成的访问日志相关数据
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:05 下午 2026/10/21
+type accessLogState struct {
+	start  time.Time      // 请求开始时间
+	body   *countReader   // 统计读取的请求body长度
+	policy *RoutePolicy   // 命中的路由策略
+	writer *captureWriter // 记录body的writer
+	cl     *canonicalLog  // 合并模式下记录的请求内日志
+	tb     *tailBuffer    // 缓存的请求内日志
+}
+
+// writeAccessLog 请求结束时记录访问日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:08 下午 2026/10/21
+func (gw *GinWrapper) writeAccessLog(ctx *gin.Context, o *AccessLogOption, state *accessLogState, status int) {
+	requestFieldList, summaryFieldList := state.cl.finish()
+	latency := time.Since(state.start)
+	if nil != state.tb {
+		// 5xx 的请求输出缓存的日志, 其余请求丢弃
+		if _, droppedCount := state.tb.finish(status >= http.StatusInternalServerError); droppedCount > 0 && status >= http.StatusInternalServerError {
+			summaryFieldList = append(summaryFieldList, zap.Int("tail_dropped", droppedCount))
+		}
+	}
+	level := o.accessLogLevel(status, latency)
+	// ctx.Error 设置的错误按错误类型提升日志级别
+	if errLevel := ginErrorList(ctx.Errors).level(); errLevel > level {
+		level = errLevel
+	}
+	if !o.needLog(state.policy, level) {
+		return
+	}
+	// 错误、请求内追加的字段、合并的日志等, 文本格式的访问日志无法记录, 仍然记录在json日志中
+	extraFieldList := make([]zap.Field, 0)
+	if len(ctx.Errors) > 0 {
+		extraFieldList = append(extraFieldList, zap.Array("errors", ginErrorList(ctx.Errors)))
+	}
+	extraFieldList = append(extraFieldList, o.captureFieldList(ctx, state.writer)...)
+	extraFieldList = append(extraFieldList, summaryFieldList...)
+	var fieldList []zap.Field
+	if nil != o.TextLogWriter {
+		// 文本格式只替换访问日志本身, 没有其他需要记录的内容时不再记录json日志
+		o.writeTextLog(ctx, state.start, latency, status)
+		if len(extraFieldList) == 0 && len(requestFieldList) == 0 {
+			return
+		}
+		fieldList = []zap.Field{
+			zap.String("method", ctx.Request.Method),
+			zap.String("path", ctx.Request.URL.Path),
+			zap.Int("status", status),
+		}
+	} else {
+		fieldList = []zap.Field{
+			zap.String("method", ctx.Request.Method),
+			zap.String("route", ctx.FullPath()),
+			zap.String("path", ctx.Request.URL.Path),
+			zap.String("query", getDebugLogOption(ctx).redactQuery(ctx.Request.URL.RawQuery)),
+			zap.Int("status", status),
+			zap.Duration("latency", latency),
+			zap.Int64("bytes_in", requestSize(ctx.Request, state.body)),
+			zap.Int("bytes_out", responseSize(ctx.Writer)),
+			zap.String("user_agent", ctx.Request.UserAgent()),
+			zap.String("client_ip", o.clientIP(ctx.Request)),
+		}
+	}
+	fieldList = append(fieldList, extraFieldList...)
+	l := gw.GetLogger(ctx)
+	fieldList = append(fieldList, mergeRequestFieldList(fieldList, l.formatFieldList(nil), requestFieldList)...)
+	switch level {
+	case zapcore.ErrorLevel:
+		l.Error(o.Message, fieldList...)
+	case zapcore.WarnLevel:
+		l.Warn(o.Message, fieldList...)
+	default:
+		l.Info(o.Message, fieldList...)
+	}
+}
+
+// accessLogLevel 访问日志的级别
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:36 下午 2026/10/19
+func (o *AccessLogOption) accessLogLevel(status int, latency time.Duration) zapcore.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return zapcore.ErrorLevel
+	case o.SlowThreshold > 0 && latency > o.SlowThreshold:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+// needLog 按路由策略判断是否记录访问日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:38 下午 2026/10/19
+func (o *AccessLogOption) needLog(policy *RoutePolicy, level zapcore.Level) bool {
+	if nil == policy {
+		return true
+	}
+	if policy.Skip || !policy.enabled(level) {
+		return false
+	}
+	return level >= zapcore.ErrorLevel || policy.sampled()
+}
+
+// clientIP 获取客户端IP, 只有来自可信代理的请求才会读取代理设置的header
+//
+// 从右向左查找 X-Forwarded-For, 第一个不是可信代理的IP即为客户端IP
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:36 下午 2026/10/19
+func (o *AccessLogOption) clientIP(req *http.Request) string {
+	remoteIP, _, err := net.SplitHostPort(strings.TrimSpace(req.RemoteAddr))
+	if nil != err {
+		remoteIP = strings.TrimSpace(req.RemoteAddr)
+	}
+	if !o.isTrustedProxy(remoteIP) {
+		return remoteIP
+	}
+	if forwardedFor := req.Header.Get("X-Forwarded-For"); len(forwardedFor) > 0 {
+		ipList := strings.Split(forwardedFor, ",")
+		for i := len(ipList) - 1; i >= 0; i-- {
+			ip := strings.TrimSpace(ipList[i])
+			if nil == net.ParseIP(ip) {
+				break
+			}
+			if !o.isTrustedProxy(ip) {
+				return ip
+			}
+		}
+	}
+	if realIP := strings.TrimSpace(req.Header.Get("X-Real-Ip")); nil != net.ParseIP(realIP) {
+		return realIP
+	}
+	return remoteIP
+}
+
+// isTrustedProxy 是否可信代理
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:40 下午 2026/10/19
+func (o *AccessLogOption) isTrustedProxy(ip string) bool {
+	parsedIP := net.ParseIP(ip)
+	if nil == parsedIP {
+		return false
+	}
+	for _, ipNet := range o.trustedNetList {
+		if ipNet.Contains(parsedIP) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxyList 解析可信代理列表, 无法解析的配置忽略
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:42 下午 2026/10/19
+func parseTrustedProxyList(proxyList []string) []*net.IPNet {
+	result := make([]*net.IPNet, 0, len(proxyList))
+	for _, proxy := range proxyList {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); nil != ip {
+				bits := 8 * net.IPv6len
+				if nil != ip.To4() {
+					ip, bits = ip.To4(), 8*net.IPv4len
+				}
+				result = append(result, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			}
+			continue
+		}
+		if _, ipNet, err := net.ParseCIDR(proxy); nil == err {
+			result = append(result, ipNet)
+		}
+	}
+	return result
+}
+
+// requestSize 请求体大小, 没有 Content-Length 时使用实际读取的字节数
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:45 下午 2026/10/19
+func requestSize(req *http.Request, body *countReader) int64 {
+	if req.ContentLength >= 0 {
+		return req.ContentLength
+	}
+	return body.size
+}
+
+// responseSize 响应体大小
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:46 下午 2026/10/19
+func responseSize(writer gin.ResponseWriter) int {
+	if size := writer.Size(); size > 0 {
+		return size
+	}
+	return 0
+}
+
+// countReader 统计读取的字节数
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:47 下午 2026/10/19
+type countReader struct {
+	io.ReadCloser
+	size int64
+}
+
+// Read ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:48 下午 2026/10/19
+func (cr *countReader) Read(p []byte) (int, error) {
+	n, err := cr.ReadCloser.Read(p)
+	cr.size += int64(n)
+	return n, err
+}
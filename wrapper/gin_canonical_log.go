@@ -0,0 +1,272 @@
+// Package wrapper...
+//
+// Description : gin_canonical_log 请求内追加的字段记录在访问日志中, 合并模式下一个请求只输出一条日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 11:40 下午
+package wrapper
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// canonicalLogContextKey 请求内追加的字段在gin上下文中的key
+	canonicalLogContextKey = "__logger_canonical_log"
+)
+
+// WithCanonicalLog 合并模式, 请求内的 Debug / Info 日志不再单独输出, 字段与message合并到访问日志中
+//
+// 合并模式下 Warn 及以上级别的日志仍然立即输出, 访问日志记录其数量
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:42 下午 2026/10/19
+func WithCanonicalLog() SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		o.CanonicalLog = true
+	}
+}
+
+// AddField 为当前请求的访问日志追加字段, 需要使用访问日志中间件, 相同的字段后设置的生效
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:44 下午 2026/10/19
+func (gw *GinWrapper) AddField(field ...zap.Field) {
+	if cl := getCanonicalLog(gw.ginCtx); nil != cl {
+		cl.addField(field)
+	}
+}
+
+// canonicalLog 一个请求内收集的字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:46 下午 2026/10/19
+type canonicalLog struct {
+	lock        sync.Mutex
+	merge       bool           // 是否合并 Debug / Info 日志
+	finished    bool           // 请求是否已经结束, 结束之后的日志直接输出
+	fieldList   []zap.Field    // 收集的字段
+	fieldIndex  map[string]int // 字段在列表中的位置, 用于去重
+	messageList []string       // 合并的日志message
+	warnCount   int            // Warn 日志数量
+	errorCount  int            // Error 及以上级别的日志数量
+}
+
+// newCanonicalLog 生成请求内收集字段的实例, 并记录在gin上下文中
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:48 下午 2026/10/19
+func newCanonicalLog(ctx *gin.Context, merge bool) *canonicalLog {
+	cl := &canonicalLog{
+		merge:       merge,
+		fieldList:   make([]zap.Field, 0),
+		fieldIndex:  make(map[string]int),
+		messageList: make([]string, 0),
+	}
+	ctx.Set(canonicalLogContextKey, cl)
+	return cl
+}
+
+// getCanonicalLog 获取请求内收集字段的实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:49 下午 2026/10/19
+func getCanonicalLog(ctx *gin.Context) *canonicalLog {
+	if nil == ctx {
+		return nil
+	}
+	if cl, exist := ctx.Get(canonicalLogContextKey); exist {
+		return cl.(*canonicalLog)
+	}
+	return nil
+}
+
+// addField 追加字段, 相同的字段覆盖
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:51 下午 2026/10/19
+func (cl *canonicalLog) addField(fieldList []zap.Field) {
+	cl.lock.Lock()
+	defer cl.lock.Unlock()
+	for _, f := range fieldList {
+		if f.Type == zapcore.SkipType {
+			continue
+		}
+		if idx, exist := cl.fieldIndex[f.Key]; exist {
+			cl.fieldList[idx] = f
+			continue
+		}
+		cl.fieldIndex[f.Key] = len(cl.fieldList)
+		cl.fieldList = append(cl.fieldList, f)
+	}
+}
+
+// addEntry 合并一条日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:53 下午 2026/10/19
+func (cl *canonicalLog) addEntry(message string, fieldList []zap.Field) {
+	cl.addField(fieldList)
+	cl.lock.Lock()
+	defer cl.lock.Unlock()
+	cl.messageList = append(cl.messageList, message)
+}
+
+// count 记录立即输出的日志数量
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:54 下午 2026/10/19
+func (cl *canonicalLog) count(level zapcore.Level) {
+	cl.lock.Lock()
+	defer cl.lock.Unlock()
+	if level >= zapcore.ErrorLevel {
+		cl.errorCount++
+		return
+	}
+	cl.warnCount++
+}
+
+// isFinished 请求是否已经结束
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:55 下午 2026/10/19
+func (cl *canonicalLog) isFinished() bool {
+	cl.lock.Lock()
+	defer cl.lock.Unlock()
+	return cl.finished
+}
+
+// finish 请求结束, 返回收集的字段以及合并模式的统计字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:57 下午 2026/10/19
+func (cl *canonicalLog) finish() ([]zap.Field, []zap.Field) {
+	cl.lock.Lock()
+	defer cl.lock.Unlock()
+	cl.finished = true
+	fieldList := make([]zap.Field, 0, len(cl.fieldList))
+	fieldList = append(fieldList, cl.fieldList...)
+	summaryFieldList := make([]zap.Field, 0, 3)
+	if !cl.merge {
+		return fieldList, summaryFieldList
+	}
+	if len(cl.messageList) > 0 {
+		summaryFieldList = append(summaryFieldList, zap.Strings("messages", cl.messageList))
+	}
+	return fieldList, append(summaryFieldList, zap.Int("warn_count", cl.warnCount), zap.Int("error_count", cl.errorCount))
+}
+
+// mergeRequestFieldList 生成合并到访问日志中的字段, 避免访问日志出现重复的key
+//
+// 访问日志实例已经携带的请求字段(请求ID、抽取的字段等)不再合并, 与访问日志自身字段重复的key增加 _1 等后缀
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:40 上午 2026/10/21
+func mergeRequestFieldList(accessFieldList []zap.Field, extractFieldList []zap.Field, requestFieldList []zap.Field) []zap.Field {
+	extractKeyTable := make(map[string]bool, len(extractFieldList))
+	for _, f := range extractFieldList {
+		extractKeyTable[f.Key] = true
+	}
+	usedKeyTable := make(map[string]bool, len(accessFieldList)+len(requestFieldList))
+	for _, f := range accessFieldList {
+		usedKeyTable[f.Key] = true
+	}
+	result := make([]zap.Field, 0, len(requestFieldList))
+	for _, f := range requestFieldList {
+		if extractKeyTable[f.Key] {
+			continue
+		}
+		if usedKeyTable[f.Key] {
+			key := f.Key
+			for idx := 1; usedKeyTable[f.Key]; idx++ {
+				f.Key = key + "_" + strconv.Itoa(idx)
+			}
+		}
+		usedKeyTable[f.Key] = true
+		result = append(result, f)
+	}
+	return result
+}
+
+// withCanonicalCore 生成合并模式的日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:59 下午 2026/10/19
+func withCanonicalCore(l *zap.Logger, cl *canonicalLog) *zap.Logger {
+	return l.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &canonicalCore{Core: core, canonicalLog: cl, withFieldList: make([]zap.Field, 0)}
+	}))
+}
+
+// canonicalCore 合并模式的core, Debug / Info 日志合并到访问日志中, 其余日志立即输出
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:01 上午 2026/10/20
+type canonicalCore struct {
+	zapcore.Core
+	canonicalLog  *canonicalLog // 请求内收集的字段
+	withFieldList []zap.Field   // With 设置的字段
+}
+
+// With ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:02 上午 2026/10/20
+func (c *canonicalCore) With(fieldList []zapcore.Field) zapcore.Core {
+	withFieldList := make([]zap.Field, 0, len(c.withFieldList)+len(fieldList))
+	return &canonicalCore{
+		Core:          c.Core.With(fieldList),
+		canonicalLog:  c.canonicalLog,
+		withFieldList: append(append(withFieldList, c.withFieldList...), fieldList...),
+	}
+}
+
+// Check ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:04 上午 2026/10/20
+func (c *canonicalCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Core.Enabled(ent.Level) {
+		return ce
+	}
+	if c.canonicalLog.isFinished() {
+		return c.Core.Check(ent, ce)
+	}
+	if ent.Level >= zapcore.WarnLevel {
+		c.canonicalLog.count(ent.Level)
+		return c.Core.Check(ent, ce)
+	}
+	return ce.AddCore(ent, c)
+}
+
+// Write 合并日志, 不输出
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:06 上午 2026/10/20
+func (c *canonicalCore) Write(ent zapcore.Entry, fieldList []zapcore.Field) error {
+	c.canonicalLog.addField(c.withFieldList)
+	c.canonicalLog.addEntry(ent.Message, fieldList)
+	return nil
+}
@@ -0,0 +1,450 @@
+// Package wrapper...
+//
+// Description : gin_capture 访问日志记录请求与响应的header、body, 支持长度限制与脱敏
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 7:05 下午
+package wrapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultMaxBodyBytes 默认记录的body最大长度
+	defaultMaxBodyBytes = 4096
+	// redactedValue 脱敏之后的值
+	redactedValue = "***"
+	// truncatedBodyFormat body 被截断的标记
+	truncatedBodyFormat = "…(truncated %d bytes)"
+)
+
+var (
+	// defaultCaptureContentTypeList 默认记录body的content-type
+	defaultCaptureContentTypeList = []string{"application/json", "application/x-www-form-urlencoded", "application/xml", "text/"}
+	// defaultHeaderDenyList 任何情况下都不会记录的header
+	defaultHeaderDenyList = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", defaultDebugLogHeader}
+)
+
+// WithCaptureHeader 记录请求与响应的header
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:08 下午 2026/10/19
+func WithCaptureHeader() SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		o.CaptureHeader = true
+	}
+}
+
+// WithCaptureBody 记录请求与响应的body, 超出最大长度的部分截断
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:09 下午 2026/10/19
+func WithCaptureBody(maxBytes int) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		o.CaptureBody = true
+		if maxBytes > 0 {
+			o.MaxBodyBytes = maxBytes
+		}
+	}
+}
+
+// WithCaptureContentType 设置记录body的content-type, 按前缀匹配, 如 : application/json, text/
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:10 下午 2026/10/19
+func WithCaptureContentType(contentTypeList ...string) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		if len(contentTypeList) == 0 {
+			return
+		}
+		o.ContentTypeList = contentTypeList
+	}
+}
+
+// WithHeaderAllowList 只记录指定的header, 不设置时记录禁止列表之外的全部header
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:11 下午 2026/10/19
+func WithHeaderAllowList(headerList ...string) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		o.HeaderAllowList = append(o.HeaderAllowList, headerList...)
+	}
+}
+
+// WithHeaderDenyList 不记录指定的header, 在默认禁止的 Authorization / Cookie 等基础上追加
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:12 下午 2026/10/19
+func WithHeaderDenyList(headerList ...string) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		o.HeaderDenyList = append(o.HeaderDenyList, headerList...)
+	}
+}
+
+// WithRedactKeyList 需要脱敏的header、json字段、表单字段, 不区分大小写
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:13 下午 2026/10/19
+func WithRedactKeyList(keyList ...string) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		o.RedactKeyList = append(o.RedactKeyList, keyList...)
+	}
+}
+
+// WithRedactFunc 自定义body的脱敏方法, 在按key脱敏之后执行
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:14 下午 2026/10/19
+func WithRedactFunc(redactFunc func(body string) string) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		if nil == redactFunc {
+			return
+		}
+		o.RedactFunc = redactFunc
+	}
+}
+
+// initCaptureOption 初始化记录header与body相关的配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:16 下午 2026/10/19
+func (o *AccessLogOption) initCaptureOption() {
+	o.headerAllowTable = make(map[string]bool)
+	for _, header := range o.HeaderAllowList {
+		o.headerAllowTable[http.CanonicalHeaderKey(header)] = true
+	}
+	o.headerDenyTable = make(map[string]bool)
+	for _, header := range append(defaultHeaderDenyList, o.HeaderDenyList...) {
+		o.headerDenyTable[http.CanonicalHeaderKey(header)] = true
+	}
+	o.redactKeyTable = make(map[string]bool)
+	for _, key := range o.RedactKeyList {
+		o.redactKeyTable[strings.ToLower(key)] = true
+	}
+}
+
+// startCapture 开始记录请求, 读取请求body后重新放回, 不影响业务读取
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:20 下午 2026/10/19
+func (o *AccessLogOption) startCapture(ctx *gin.Context, policy *RoutePolicy) *captureWriter {
+	if !o.CaptureBody || (nil != policy && policy.SkipBodyCapture) {
+		return nil
+	}
+	writer := &captureWriter{ResponseWriter: ctx.Writer, maxBytes: o.MaxBodyBytes}
+	if nil != ctx.Request.Body && o.isCaptureContentType(ctx.GetHeader("Content-Type")) {
+		body := ctx.Request.Body
+		writer.requestBody, _ = ioutil.ReadAll(io.LimitReader(body, int64(o.MaxBodyBytes)+1))
+		writer.hasRequestBody = true
+		ctx.Request.Body = &multiReadCloser{Reader: io.MultiReader(bytes.NewReader(writer.requestBody), body), Closer: body}
+	}
+	ctx.Writer = writer
+	return writer
+}
+
+// captureFieldList 生成记录header与body的字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:25 下午 2026/10/19
+func (o *AccessLogOption) captureFieldList(ctx *gin.Context, writer *captureWriter) []zap.Field {
+	fieldList := make([]zap.Field, 0, 4)
+	if o.CaptureHeader {
+		requestHeader := o.formatHeader(ctx.Request.Header)
+		// 开启Debug日志的密钥不记录
+		if debugLogOption := getDebugLogOption(ctx); nil != debugLogOption && len(debugLogOption.HeaderName) > 0 {
+			delete(requestHeader, http.CanonicalHeaderKey(debugLogOption.HeaderName))
+		}
+		fieldList = append(fieldList,
+			zap.Any("request_header", requestHeader),
+			zap.Any("response_header", o.formatHeader(ctx.Writer.Header())),
+		)
+	}
+	if nil == writer {
+		return fieldList
+	}
+	if writer.hasRequestBody {
+		fieldList = append(fieldList, zap.String("request_body", o.formatBody(ctx.GetHeader("Content-Type"), writer.requestBody, ctx.Request.ContentLength)))
+	}
+	if contentType := writer.Header().Get("Content-Type"); o.isCaptureContentType(contentType) {
+		fieldList = append(fieldList, zap.String("response_body", o.formatBody(contentType, writer.body.Bytes(), int64(writer.size))))
+	}
+	return fieldList
+}
+
+// isCaptureContentType 是否记录该content-type的body
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:28 下午 2026/10/19
+func (o *AccessLogOption) isCaptureContentType(contentType string) bool {
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	for _, allowType := range o.ContentTypeList {
+		if strings.HasPrefix(contentType, strings.ToLower(allowType)) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatHeader 按允许列表与禁止列表过滤header, 并脱敏
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:30 下午 2026/10/19
+func (o *AccessLogOption) formatHeader(header http.Header) map[string]string {
+	result := make(map[string]string)
+	for key, valueList := range header {
+		key = http.CanonicalHeaderKey(key)
+		if o.headerDenyTable[key] {
+			continue
+		}
+		if len(o.headerAllowTable) > 0 && !o.headerAllowTable[key] {
+			continue
+		}
+		if o.redactKeyTable[strings.ToLower(key)] {
+			result[key] = redactedValue
+			continue
+		}
+		result[key] = strings.Join(valueList, ", ")
+	}
+	return result
+}
+
+// formatBody 截断并脱敏body
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:33 下午 2026/10/19
+func (o *AccessLogOption) formatBody(contentType string, byteData []byte, totalSize int64) string {
+	var marker string
+	if len(byteData) > o.MaxBodyBytes {
+		byteData = byteData[:o.MaxBodyBytes]
+		if totalSize > int64(o.MaxBodyBytes) {
+			marker = fmt.Sprintf(truncatedBodyFormat, totalSize-int64(o.MaxBodyBytes))
+		} else {
+			marker = "…(truncated)"
+		}
+	}
+	body := string(byteData)
+	if len(o.redactKeyTable) > 0 {
+		contentType = strings.ToLower(contentType)
+		switch {
+		case strings.Contains(contentType, "json"):
+			body = o.redactJSON(body)
+		case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+			body = o.redactForm(body)
+		}
+	}
+	if nil != o.RedactFunc {
+		body = o.RedactFunc(body)
+	}
+	return body + marker
+}
+
+// redactJSON json body 脱敏, 只替换需要脱敏的值, 其余内容保持原样
+//
+// 截断后的json解析到末尾会失败, 失败时正在读取需要脱敏的值, 则剩余部分全部脱敏
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:36 下午 2026/10/19
+func (o *AccessLogOption) redactJSON(body string) string {
+	r := &jsonRedactor{
+		body:     body,
+		decoder:  json.NewDecoder(strings.NewReader(body)),
+		keyTable: o.redactKeyTable,
+	}
+	// body 中可能有多个json, 依次处理, 无法解析时停止
+	for {
+		if err := r.walkValue(); nil != err {
+			break
+		}
+	}
+	if len(r.rangeList) == 0 {
+		return body
+	}
+	var builder strings.Builder
+	builder.Grow(len(body))
+	start := 0
+	for _, valueRange := range r.rangeList {
+		builder.WriteString(body[start:valueRange[0]])
+		builder.WriteString(`"` + redactedValue + `"`)
+		start = valueRange[1]
+	}
+	builder.WriteString(body[start:])
+	return builder.String()
+}
+
+// jsonRedactor 按token遍历json, 记录需要脱敏的值在body中的位置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:05 上午 2026/10/21
+type jsonRedactor struct {
+	body      string          // 原始body
+	decoder   *json.Decoder   // 读取body的decoder
+	keyTable  map[string]bool // 需要脱敏的key
+	rangeList [][2]int        // 需要脱敏的值的起止位置
+}
+
+// walkValue 读取一个json值, 对象中需要脱敏的key对应的值记录位置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:08 上午 2026/10/21
+func (r *jsonRedactor) walkValue() error {
+	token, err := r.decoder.Token()
+	if nil != err {
+		return err
+	}
+	switch token {
+	case json.Delim('{'):
+		for r.decoder.More() {
+			if token, err = r.decoder.Token(); nil != err {
+				return err
+			}
+			if key, ok := token.(string); ok && r.keyTable[strings.ToLower(key)] {
+				err = r.skipValue()
+			} else {
+				err = r.walkValue()
+			}
+			if nil != err {
+				return err
+			}
+		}
+		_, err = r.decoder.Token()
+		return err
+	case json.Delim('['):
+		for r.decoder.More() {
+			if err = r.walkValue(); nil != err {
+				return err
+			}
+		}
+		_, err = r.decoder.Token()
+		return err
+	default:
+		return nil
+	}
+}
+
+// skipValue 跳过需要脱敏的值并记录位置, 值不完整时脱敏剩余的全部内容
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:12 上午 2026/10/21
+func (r *jsonRedactor) skipValue() error {
+	// decoder 的位置在key之后, 值从冒号之后第一个非空白字符开始
+	start := int(r.decoder.InputOffset())
+	for start < len(r.body) && strings.IndexByte(" \t\r\n:", r.body[start]) >= 0 {
+		start++
+	}
+	if start >= len(r.body) {
+		return io.ErrUnexpectedEOF
+	}
+	var value json.RawMessage
+	if err := r.decoder.Decode(&value); nil != err {
+		r.rangeList = append(r.rangeList, [2]int{start, len(r.body)})
+		return err
+	}
+	r.rangeList = append(r.rangeList, [2]int{start, int(r.decoder.InputOffset())})
+	return nil
+}
+
+// redactForm 表单 body 脱敏
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:42 下午 2026/10/19
+func (o *AccessLogOption) redactForm(body string) string {
+	values, err := url.ParseQuery(body)
+	if nil != err {
+		return body
+	}
+	for key := range values {
+		if o.redactKeyTable[strings.ToLower(key)] {
+			values[key] = []string{redactedValue}
+		}
+	}
+	return values.Encode()
+}
+
+// multiReadCloser 读取过的请求body与剩余部分拼接后重新放回请求
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:44 下午 2026/10/19
+type multiReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// captureWriter 记录请求body与响应body, 超出最大长度的部分不记录
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:45 下午 2026/10/19
+type captureWriter struct {
+	gin.ResponseWriter
+	body           bytes.Buffer // 响应body
+	maxBytes       int          // 记录的最大长度
+	size           int          // 响应body的实际长度
+	requestBody    []byte       // 请求body
+	hasRequestBody bool         // 是否记录了请求body
+}
+
+// Write ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:46 下午 2026/10/19
+func (cw *captureWriter) Write(data []byte) (int, error) {
+	cw.capture(data)
+	return cw.ResponseWriter.Write(data)
+}
+
+// WriteString ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:47 下午 2026/10/19
+func (cw *captureWriter) WriteString(data string) (int, error) {
+	cw.capture([]byte(data))
+	return cw.ResponseWriter.WriteString(data)
+}
+
+// capture 记录响应数据, 多记录一个字节用于判断是否截断
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:48 下午 2026/10/19
+func (cw *captureWriter) capture(data []byte) {
+	cw.size += len(data)
+	if remain := cw.maxBytes + 1 - cw.body.Len(); remain > 0 {
+		if len(data) > remain {
+			data = data[:remain]
+		}
+		cw.body.Write(data)
+	}
+}
@@ -0,0 +1,64 @@
+// Package wrapper...
+//
+// Description : gin_context 每个请求只生成一次日志实例, 记录在gin上下文以及请求的 context.Context 中
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 2:50 上午
+package wrapper
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-developer/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// ginWrapperContextKey 请求的日志实例在gin上下文中的key
+	ginWrapperContextKey = "__logger_gin_wrapper"
+)
+
+// ContextMiddleware 生成请求的日志实例, 记录在gin上下文以及 ctx.Request.Context() 中
+//
+// 通过 FromGin 获取 GinWrapper, 通过 logger.FromContext 或者 logger.Ctx 获取携带请求字段的zap实例.
+// 需要注册在请求ID、访问日志等中间件之后.
+// 链路追踪字段已经由 TraceMiddleware 记录在 ctx.Request.Context() 中, 由 logger.FromContext 以及 logger.Ctx 添加, 日志实例不再重复携带.
+// context.Context 中的zap实例携带的是中间件执行时抽取的请求字段, 之后gin上下文中新增或者修改的数据只有 FromGin 获取的实例会重新抽取
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:53 上午 2026/10/20
+func (gw *GinWrapper) ContextMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestLogger := gw.GetLogger(ctx)
+		ctx.Set(ginWrapperContextKey, requestLogger)
+		traceKeyTable := make(map[string]bool)
+		for _, f := range getTraceFieldList(ctx) {
+			traceKeyTable[f.Key] = true
+		}
+		fieldList := make([]zap.Field, 0)
+		for _, f := range requestLogger.formatFieldList(nil) {
+			if !traceKeyTable[f.Key] {
+				fieldList = append(fieldList, f)
+			}
+		}
+		zapLogger := requestLogger.loggerInstance.With(fieldList...)
+		ctx.Request = ctx.Request.WithContext(logger.NewContext(ctx.Request.Context(), zapLogger))
+		ctx.Next()
+	}
+}
+
+// FromGin 获取 ContextMiddleware 生成的请求日志实例, 没有使用中间件时返回nil
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:56 上午 2026/10/20
+func FromGin(ctx *gin.Context) *GinWrapper {
+	if nil == ctx {
+		return nil
+	}
+	if gw, exist := ctx.Get(ginWrapperContextKey); exist {
+		return gw.(*GinWrapper)
+	}
+	return nil
+}
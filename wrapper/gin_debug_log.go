@@ -0,0 +1,225 @@
+// Package wrapper...
+//
+// Description : gin_debug_log 单个请求通过header或者query开启Debug日志, 不需要调整全局的日志级别
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 11:05 下午
+package wrapper
+
+import (
+	"crypto/subtle"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// defaultDebugLogHeader 默认开启Debug日志的header
+	defaultDebugLogHeader = "X-Debug-Log"
+	// defaultDebugLogQuery 默认开启Debug日志的query参数
+	defaultDebugLogQuery = "debug_log"
+	// debugLogContextKey 是否开启Debug日志在gin上下文中的key
+	debugLogContextKey = "__logger_debug_log"
+	// debugLogOptionContextKey Debug日志配置在gin上下文中的key, 访问日志据此隐藏密钥
+	debugLogOptionContextKey = "__logger_debug_log_option"
+)
+
+// DebugLogOption 请求级别Debug日志的配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:07 下午 2026/10/19
+type DebugLogOption struct {
+	HeaderName string // 读取密钥的header, 为空不从header读取
+	QueryName  string // 读取密钥的query参数, 为空不从query读取
+	Secret     string // 共享密钥, 请求携带的值与密钥一致时才开启
+}
+
+// SetDebugLogOptionFunc 设置请求级别Debug日志的配置
+type SetDebugLogOptionFunc func(o *DebugLogOption)
+
+// WithDebugLogHeader 设置读取密钥的header, 传入空字符串表示不从header读取
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:09 下午 2026/10/19
+func WithDebugLogHeader(headerName string) SetDebugLogOptionFunc {
+	return func(o *DebugLogOption) {
+		o.HeaderName = strings.Trim(headerName, " ")
+	}
+}
+
+// WithDebugLogQuery 设置读取密钥的query参数, 传入空字符串表示不从query读取
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:10 下午 2026/10/19
+func WithDebugLogQuery(queryName string) SetDebugLogOptionFunc {
+	return func(o *DebugLogOption) {
+		o.QueryName = strings.Trim(queryName, " ")
+	}
+}
+
+// WithDebugLogger 设置输出Debug日志的实例, 可以写入单独的Debug日志文件
+//
+// 通过 NewGinWrapperLogger 创建时, 默认写入原有的日志输出
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:12 下午 2026/10/19
+func WithDebugLogger(l *zap.Logger) SetGinWrapperOptionFunc {
+	return func(o *GinWrapperOption) {
+		if nil == l {
+			return
+		}
+		o.DebugLogger = l
+	}
+}
+
+// DebugLogMiddleware 请求级别Debug日志中间件, 请求的header或者query携带的值与密钥一致时, 该请求的日志记录Debug级别
+//
+// 密钥为空时不会为任何请求开启, 访问日志不会记录携带密钥的header, query中的密钥会被替换为 ***
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:15 下午 2026/10/19
+func DebugLogMiddleware(secret string, option ...SetDebugLogOptionFunc) gin.HandlerFunc {
+	o := &DebugLogOption{
+		HeaderName: defaultDebugLogHeader,
+		QueryName:  defaultDebugLogQuery,
+		Secret:     secret,
+	}
+	for _, f := range option {
+		f(o)
+	}
+	return func(ctx *gin.Context) {
+		ctx.Set(debugLogOptionContextKey, o)
+		if o.match(ctx) {
+			ctx.Set(debugLogContextKey, true)
+		}
+		ctx.Next()
+	}
+}
+
+// match 请求是否携带了正确的密钥
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:17 下午 2026/10/19
+func (o *DebugLogOption) match(ctx *gin.Context) bool {
+	if len(o.Secret) == 0 {
+		return false
+	}
+	token := ""
+	if len(o.HeaderName) > 0 {
+		token = ctx.GetHeader(o.HeaderName)
+	}
+	if len(token) == 0 && len(o.QueryName) > 0 {
+		token = ctx.Query(o.QueryName)
+	}
+	return len(token) > 0 && subtle.ConstantTimeCompare([]byte(token), []byte(o.Secret)) == 1
+}
+
+// getDebugLogOption 获取当前请求使用的Debug日志配置, 未使用 DebugLogMiddleware 时返回nil
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:05 上午 2026/10/21
+func getDebugLogOption(ctx *gin.Context) *DebugLogOption {
+	value, exist := ctx.Get(debugLogOptionContextKey)
+	if !exist {
+		return nil
+	}
+	o, _ := value.(*DebugLogOption)
+	return o
+}
+
+// redactQuery 隐藏query中的密钥, 其余参数保持原样
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:08 上午 2026/10/21
+func (o *DebugLogOption) redactQuery(rawQuery string) string {
+	if nil == o || len(o.QueryName) == 0 || len(rawQuery) == 0 {
+		return rawQuery
+	}
+	partList := strings.Split(rawQuery, "&")
+	for idx, part := range partList {
+		key := part
+		if pos := strings.Index(part, "="); pos >= 0 {
+			key = part[:pos]
+		}
+		if unescapedKey, err := url.QueryUnescape(key); nil == err && unescapedKey == o.QueryName {
+			partList[idx] = key + "=" + redactedValue
+		}
+	}
+	return strings.Join(partList, "&")
+}
+
+// IsDebugLog 当前请求是否开启了Debug日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:18 下午 2026/10/19
+func IsDebugLog(ctx *gin.Context) bool {
+	if nil == ctx {
+		return false
+	}
+	return ctx.GetBool(debugLogContextKey)
+}
+
+// withDebugCore 生成额外输出Debug日志的实例, 原有实例未开启的日志级别写入Debug日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:21 下午 2026/10/19
+func (gw *GinWrapper) withDebugCore(l *zap.Logger, debugLogger *zap.Logger) *zap.Logger {
+	debugCore := debugLogger.Core().With(gw.fieldList)
+	return l.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, &debugOnlyCore{Core: debugCore, baseEnabler: core})
+	}))
+}
+
+// debugOnlyCore 只记录原有实例未开启的日志级别, 避免同一条日志记录两次
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:23 下午 2026/10/19
+type debugOnlyCore struct {
+	zapcore.Core
+	baseEnabler zapcore.LevelEnabler // 原有实例的日志级别
+}
+
+// Enabled ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:24 下午 2026/10/19
+func (c *debugOnlyCore) Enabled(level zapcore.Level) bool {
+	return !c.baseEnabler.Enabled(level) && c.Core.Enabled(level)
+}
+
+// With ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:25 下午 2026/10/19
+func (c *debugOnlyCore) With(fieldList []zapcore.Field) zapcore.Core {
+	return &debugOnlyCore{Core: c.Core.With(fieldList), baseEnabler: c.baseEnabler}
+}
+
+// Check ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:26 下午 2026/10/19
+func (c *debugOnlyCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
@@ -0,0 +1,126 @@
+// Package wrapper...
+//
+// Description : gin_errors 访问日志记录 ctx.Error 设置的错误, 包括错误类型、meta以及原始错误
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 1:05 上午
+package wrapper
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap/zapcore"
+)
+
+// ginErrorTypeNameList gin错误类型每一位对应的名称
+var ginErrorTypeNameList = []struct {
+	errorType gin.ErrorType
+	name      string
+}{
+	{gin.ErrorTypeBind, "bind"},
+	{gin.ErrorTypeRender, "render"},
+	{gin.ErrorTypePrivate, "private"},
+	{gin.ErrorTypePublic, "public"},
+}
+
+// ginErrorList 结构化的gin错误列表
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:07 上午 2026/10/20
+type ginErrorList []*gin.Error
+
+// MarshalLogArray ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:08 上午 2026/10/20
+func (gel ginErrorList) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, ginErr := range gel {
+		ginErr := ginErr
+		if err := enc.AppendObject(zapcore.ObjectMarshalerFunc(func(oe zapcore.ObjectEncoder) error {
+			oe.AddString("type", ginErrorTypeName(ginErr.Type))
+			oe.AddString("message", ginErr.Error())
+			if nil != ginErr.Meta {
+				if err := oe.AddReflected("meta", ginErr.Meta); nil != err {
+					return err
+				}
+			}
+			if cause := rootCause(ginErr.Err); nil != cause && cause != ginErr.Err {
+				oe.AddString("cause", cause.Error())
+			}
+			return nil
+		})); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// level 按错误类型选择日志级别, 参数绑定错误、可以返回给客户端的错误以及客户端断开连接使用Warn, 其余使用Error
+//
+// gin 的 IsType 按位判断, ErrorTypeAny 包含全部的位, 所以错误类型需要完全相等
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:12 上午 2026/10/20
+func (gel ginErrorList) level() zapcore.Level {
+	level := zapcore.InfoLevel
+	for _, ginErr := range gel {
+		errLevel := zapcore.ErrorLevel
+		var brokenPipeErr brokenPipeError
+		if ginErr.Type == gin.ErrorTypeBind || ginErr.Type == gin.ErrorTypePublic || errors.As(ginErr.Err, &brokenPipeErr) {
+			errLevel = zapcore.WarnLevel
+		}
+		if errLevel > level {
+			level = errLevel
+		}
+	}
+	return level
+}
+
+// ginErrorTypeName 错误类型的名称, 错误类型是按位组合的, 多个类型使用 | 连接
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:15 上午 2026/10/20
+func ginErrorTypeName(errorType gin.ErrorType) string {
+	if errorType == gin.ErrorTypeAny {
+		return "any"
+	}
+	nameList := make([]string, 0, 1)
+	for _, item := range ginErrorTypeNameList {
+		if errorType&item.errorType != 0 {
+			nameList = append(nameList, item.name)
+			errorType &^= item.errorType
+		}
+	}
+	// 没有名称的位按十六进制输出
+	if errorType != 0 || len(nameList) == 0 {
+		nameList = append(nameList, "0x"+strconv.FormatUint(uint64(errorType), 16))
+	}
+	return strings.Join(nameList, "|")
+}
+
+// rootCause 获取被包装的原始错误, 兼容 errors.Unwrap 与 github.com/pkg/errors 的 Cause
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:17 上午 2026/10/20
+func rootCause(err error) error {
+	for nil != err {
+		next := errors.Unwrap(err)
+		if causer, ok := err.(interface{ Cause() error }); ok && nil == next {
+			next = causer.Cause()
+		}
+		if nil == next {
+			return err
+		}
+		err = next
+	}
+	return err
+}
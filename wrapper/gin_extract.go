@@ -0,0 +1,124 @@
+// Package wrapper...
+//
+// Description : gin_extract 从gin请求中抽取字段, 支持header、query、路径参数、cookie、上下文以及自定义方法
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 9:30 下午
+package wrapper
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ExtractSource 抽取字段的来源
+type ExtractSource uint
+
+const (
+	// ExtractSourceContext 从gin上下文中抽取, 即 ctx.Set 设置的数据
+	ExtractSourceContext = ExtractSource(0)
+	// ExtractSourceHeader 从请求header中抽取
+	ExtractSourceHeader = ExtractSource(1)
+	// ExtractSourceQuery 从query参数中抽取
+	ExtractSourceQuery = ExtractSource(2)
+	// ExtractSourceParam 从路径参数中抽取
+	ExtractSourceParam = ExtractSource(3)
+	// ExtractSourceCookie 从cookie中抽取
+	ExtractSourceCookie = ExtractSource(4)
+)
+
+// ExtractField 抽取字段的配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:32 下午 2026/10/19
+type ExtractField struct {
+	Source ExtractSource // 字段来源
+	Key    string        // 来源中的key
+	Rename string        // 日志中的字段名, 为空时使用Key
+}
+
+// FieldExtractor 自定义抽取字段的方法, 如 : 从JWT中解析用户ID
+type FieldExtractor func(ctx *gin.Context) []zap.Field
+
+// WithExtractField 设置从请求中抽取的字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:36 下午 2026/10/19
+func WithExtractField(extractFieldList ...ExtractField) SetGinWrapperOptionFunc {
+	return func(o *GinWrapperOption) {
+		o.ExtractFieldList = append(o.ExtractFieldList, extractFieldList...)
+	}
+}
+
+// WithFieldExtractor 设置自定义抽取字段的方法
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:37 下午 2026/10/19
+func WithFieldExtractor(extractorList ...FieldExtractor) SetGinWrapperOptionFunc {
+	return func(o *GinWrapperOption) {
+		for _, extractor := range extractorList {
+			if nil != extractor {
+				o.FieldExtractorList = append(o.FieldExtractorList, extractor)
+			}
+		}
+	}
+}
+
+// fieldKey 日志中的字段名
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:38 下午 2026/10/19
+func (ef ExtractField) fieldKey() string {
+	if len(ef.Rename) > 0 {
+		return ef.Rename
+	}
+	return ef.Key
+}
+
+// extract 抽取字段, 上下文中的数据保持原有类型, 数据不存在时返回false
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:40 下午 2026/10/19
+func (ef ExtractField) extract(ctx *gin.Context) (zap.Field, bool) {
+	switch ef.Source {
+	case ExtractSourceContext:
+		if v, exist := ctx.Get(ef.Key); exist {
+			return zap.Any(ef.fieldKey(), v), true
+		}
+	case ExtractSourceHeader:
+		if valueList := ctx.Request.Header.Values(ef.Key); len(valueList) > 0 {
+			return stringListField(ef.fieldKey(), valueList), true
+		}
+	case ExtractSourceQuery:
+		if valueList, exist := ctx.GetQueryArray(ef.Key); exist {
+			return stringListField(ef.fieldKey(), valueList), true
+		}
+	case ExtractSourceParam:
+		if value, exist := ctx.Params.Get(ef.Key); exist {
+			return zap.String(ef.fieldKey(), value), true
+		}
+	case ExtractSourceCookie:
+		if value, err := ctx.Cookie(ef.Key); nil == err {
+			return zap.String(ef.fieldKey(), value), true
+		}
+	}
+	return zap.Skip(), false
+}
+
+// stringListField 只有一个值时记录为字符串, 多个值时记录为数组
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:43 下午 2026/10/19
+func stringListField(key string, valueList []string) zap.Field {
+	if len(valueList) == 1 {
+		return zap.String(key, valueList[0])
+	}
+	return zap.Strings(key, valueList)
+}
@@ -0,0 +1,202 @@
+// Package wrapper...
+//
+// Description : gin_recovery 捕获请求处理过程中的panic, 通过日志实例记录
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 8:10 下午
+package wrapper
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// defaultRecoveryMessage panic 日志默认的message
+	defaultRecoveryMessage = "http panic recovered"
+	// brokenPipeMessage 客户端断开连接的日志message
+	brokenPipeMessage = "http client connection broken"
+	// maxStackDepth 记录的最大堆栈深度
+	maxStackDepth = 64
+)
+
+// RecoveryOption panic 捕获的配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:12 下午 2026/10/19
+type RecoveryOption struct {
+	Message         string                                        // panic 日志的message
+	RecoveryHandler func(ctx *gin.Context, recovered interface{}) // 记录日志之后的响应处理
+}
+
+// SetRecoveryOptionFunc 设置panic捕获的配置
+type SetRecoveryOptionFunc func(o *RecoveryOption)
+
+// WithRecoveryMessage 设置panic日志的message
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:14 下午 2026/10/19
+func WithRecoveryMessage(message string) SetRecoveryOptionFunc {
+	return func(o *RecoveryOption) {
+		message = strings.Trim(message, " ")
+		if len(message) == 0 {
+			return
+		}
+		o.Message = message
+	}
+}
+
+// WithRecoveryHandler 设置记录日志之后的响应处理, 默认返回500
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:15 下午 2026/10/19
+func WithRecoveryHandler(handler func(ctx *gin.Context, recovered interface{})) SetRecoveryOptionFunc {
+	return func(o *RecoveryOption) {
+		if nil == handler {
+			return
+		}
+		o.RecoveryHandler = handler
+	}
+}
+
+// RecoveryMiddleware panic 捕获中间件, 记录panic的值、堆栈以及请求信息, 然后按配置响应
+//
+// 客户端断开连接导致的panic使用Warn级别记录, 并且不再响应
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:18 下午 2026/10/19
+func (gw *GinWrapper) RecoveryMiddleware(option ...SetRecoveryOptionFunc) gin.HandlerFunc {
+	o := &RecoveryOption{
+		Message: defaultRecoveryMessage,
+		RecoveryHandler: func(ctx *gin.Context, recovered interface{}) {
+			ctx.AbortWithStatus(http.StatusInternalServerError)
+		},
+	}
+	for _, f := range option {
+		f(o)
+	}
+	return func(ctx *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if nil == recovered {
+				return
+			}
+			fieldList := []zap.Field{
+				zap.Any("panic", recovered),
+				zap.Array("stack", callerStack(3)),
+				zap.String("method", ctx.Request.Method),
+				zap.String("route", ctx.FullPath()),
+				zap.String("path", ctx.Request.URL.Path),
+				zap.String("client_ip", ctx.ClientIP()),
+			}
+			l := gw.GetLogger(ctx)
+			if err, ok := recovered.(error); ok && isBrokenPipe(err) {
+				l.Warn(brokenPipeMessage, fieldList...)
+				_ = ctx.Error(brokenPipeError{error: err})
+				ctx.Abort()
+				return
+			}
+			l.Error(o.Message, fieldList...)
+			o.RecoveryHandler(ctx, recovered)
+		}()
+		ctx.Next()
+	}
+}
+
+// brokenPipeError 客户端断开连接的错误, 访问日志据此使用Warn级别
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:05 上午 2026/10/21
+type brokenPipeError struct {
+	error
+}
+
+// Unwrap ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:06 上午 2026/10/21
+func (e brokenPipeError) Unwrap() error {
+	return e.error
+}
+
+// isBrokenPipe 是否客户端断开连接
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:24 下午 2026/10/19
+func isBrokenPipe(err error) bool {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	var syscallErr *os.SyscallError
+	if !errors.As(opErr, &syscallErr) {
+		return false
+	}
+	errMsg := strings.ToLower(syscallErr.Error())
+	return strings.Contains(errMsg, "broken pipe") || strings.Contains(errMsg, "connection reset by peer")
+}
+
+// callerStack 获取调用堆栈, 跳过 runtime 包内部的调用
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:27 下午 2026/10/19
+func callerStack(skip int) stackFrameList {
+	pcList := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip, pcList)
+	frames := runtime.CallersFrames(pcList[:n])
+	result := make(stackFrameList, 0, n)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			result = append(result, frame)
+		}
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+// stackFrameList 结构化的堆栈
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:30 下午 2026/10/19
+type stackFrameList []runtime.Frame
+
+// MarshalLogArray ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:31 下午 2026/10/19
+func (sfl stackFrameList) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, frame := range sfl {
+		frame := frame
+		if err := enc.AppendObject(zapcore.ObjectMarshalerFunc(func(oe zapcore.ObjectEncoder) error {
+			oe.AddString("func", frame.Function)
+			oe.AddString("file", frame.File)
+			oe.AddInt("line", frame.Line)
+			return nil
+		})); nil != err {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Package wrapper...
+//
+// Description : gin_redirect 将gin框架自身的输出(路由注册、调试信息、Logger中间件)重定向到日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 4:00 上午
+package wrapper
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-developer/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// ginRouteMessage 路由注册日志的message
+	ginRouteMessage = "gin route registered"
+)
+
+var (
+	// ginLevelPrefixTable gin输出中表示日志级别的前缀
+	ginLevelPrefixTable = map[string]zapcore.Level{
+		"[WARNING] ": zapcore.WarnLevel,
+		"[ERROR] ":   zapcore.ErrorLevel,
+	}
+)
+
+// GinLogOption gin框架输出重定向的配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:02 上午 2026/10/20
+type GinLogOption struct {
+	WriterLevel      zapcore.Level // gin.DefaultWriter 输出的日志级别, 带 [WARNING] 的输出使用Warn级别
+	ErrorWriterLevel zapcore.Level // gin.DefaultErrorWriter 输出的日志级别
+	RouteLevel       zapcore.Level // 路由注册的日志级别
+}
+
+// SetGinLogOptionFunc 设置gin框架输出重定向的配置
+type SetGinLogOptionFunc func(o *GinLogOption)
+
+// WithGinWriterLevel 设置 gin.DefaultWriter 输出的日志级别
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:04 上午 2026/10/20
+func WithGinWriterLevel(level zapcore.Level) SetGinLogOptionFunc {
+	return func(o *GinLogOption) {
+		o.WriterLevel = level
+	}
+}
+
+// WithGinErrorWriterLevel 设置 gin.DefaultErrorWriter 输出的日志级别
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:05 上午 2026/10/20
+func WithGinErrorWriterLevel(level zapcore.Level) SetGinLogOptionFunc {
+	return func(o *GinLogOption) {
+		o.ErrorWriterLevel = level
+	}
+}
+
+// WithGinRouteLevel 设置路由注册的日志级别
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:06 上午 2026/10/20
+func WithGinRouteLevel(level zapcore.Level) SetGinLogOptionFunc {
+	return func(o *GinLogOption) {
+		o.RouteLevel = level
+	}
+}
+
+// RedirectGinLog 将 gin.DefaultWriter、gin.DefaultErrorWriter、gin.DebugPrintRouteFunc 重定向到日志实例, 返回恢复原有设置的方法
+//
+// 需要在创建 gin.Engine 以及使用 gin.Logger() 之前调用, 路由注册的日志记录 method、path、handler 字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:10 上午 2026/10/20
+func (gw *GinWrapper) RedirectGinLog(option ...SetGinLogOptionFunc) func() {
+	o := &GinLogOption{
+		WriterLevel:      zapcore.DebugLevel,
+		ErrorWriterLevel: zapcore.ErrorLevel,
+		RouteLevel:       zapcore.DebugLevel,
+	}
+	for _, f := range option {
+		f(o)
+	}
+	// gin框架的输出没有有意义的调用文件
+	l := gw.loggerInstance.WithOptions(zap.WithCaller(false)).With(zap.String("component", "gin"))
+	writer, errorWriter, routeFunc := gin.DefaultWriter, gin.DefaultErrorWriter, gin.DebugPrintRouteFunc
+	gin.DefaultWriter = logger.NewLineWriter(l, o.WriterLevel, logger.WithLineFormatter(formatGinLine))
+	gin.DefaultErrorWriter = logger.NewLineWriter(l, o.ErrorWriterLevel, logger.WithLineFormatter(formatGinLine))
+	gin.DebugPrintRouteFunc = func(httpMethod string, absolutePath string, handlerName string, handlerCount int) {
+		if ce := l.Check(o.RouteLevel, ginRouteMessage); nil != ce {
+			ce.Write(
+				zap.String("method", httpMethod),
+				zap.String("path", absolutePath),
+				zap.String("handler", handlerName),
+				zap.Int("handler_count", handlerCount),
+			)
+		}
+	}
+	return func() {
+		gin.DefaultWriter, gin.DefaultErrorWriter, gin.DebugPrintRouteFunc = writer, errorWriter, routeFunc
+	}
+}
+
+// formatGinLine 去掉gin输出的前缀, 带 [WARNING] / [ERROR] 的输出提升日志级别
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:19 上午 2026/10/20
+func formatGinLine(line string, level zapcore.Level) (string, zapcore.Level) {
+	line = strings.TrimPrefix(strings.TrimPrefix(line, "[GIN-debug] "), "[GIN] ")
+	for prefix, prefixLevel := range ginLevelPrefixTable {
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		line = strings.TrimPrefix(line, prefix)
+		if prefixLevel > level {
+			level = prefixLevel
+		}
+	}
+	return strings.TrimSpace(line), level
+}
@@ -0,0 +1,133 @@
+// Package wrapper...
+//
+// Description : gin_request_id 读取或生成请求ID, 同一个请求的所有日志都会携带
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 6:20 下午
+package wrapper
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RequestIDField 日志中请求ID的字段名
+	RequestIDField = "request_id"
+	// defaultRequestIDHeader 默认读取与返回请求ID的header
+	defaultRequestIDHeader = "X-Request-Id"
+	// requestIDContextKey 请求ID在gin上下文中的key
+	requestIDContextKey = "__logger_request_id"
+	// maxRequestIDLength 请求中携带的请求ID的最大长度, 超出时重新生成
+	maxRequestIDLength = 128
+)
+
+// RequestIDOption 请求ID的配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:22 下午 2026/10/19
+type RequestIDOption struct {
+	HeaderName string        // 读取与返回请求ID的header
+	Generator  func() string // 生成请求ID的方法
+}
+
+// SetRequestIDOptionFunc 设置请求ID的配置
+type SetRequestIDOptionFunc func(o *RequestIDOption)
+
+// WithRequestIDHeader 设置读取与返回请求ID的header
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:24 下午 2026/10/19
+func WithRequestIDHeader(headerName string) SetRequestIDOptionFunc {
+	return func(o *RequestIDOption) {
+		headerName = strings.Trim(headerName, " ")
+		if len(headerName) == 0 {
+			return
+		}
+		o.HeaderName = headerName
+	}
+}
+
+// WithRequestIDGenerator 设置生成请求ID的方法
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:25 下午 2026/10/19
+func WithRequestIDGenerator(generator func() string) SetRequestIDOptionFunc {
+	return func(o *RequestIDOption) {
+		if nil == generator {
+			return
+		}
+		o.Generator = generator
+	}
+}
+
+// RequestIDMiddleware 请求ID中间件, 优先使用请求header中的请求ID, 没有时生成, 并在响应header中返回
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:27 下午 2026/10/19
+func RequestIDMiddleware(option ...SetRequestIDOptionFunc) gin.HandlerFunc {
+	o := &RequestIDOption{
+		HeaderName: defaultRequestIDHeader,
+		Generator:  generateRequestID,
+	}
+	for _, f := range option {
+		f(o)
+	}
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(o.HeaderName)
+		if !isValidRequestID(requestID) {
+			requestID = o.Generator()
+		}
+		ctx.Set(requestIDContextKey, requestID)
+		ctx.Header(o.HeaderName, requestID)
+		ctx.Next()
+	}
+}
+
+// GetRequestID 获取当前请求的请求ID
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:30 下午 2026/10/19
+func GetRequestID(ctx *gin.Context) string {
+	if nil == ctx {
+		return ""
+	}
+	return ctx.GetString(requestIDContextKey)
+}
+
+// generateRequestID 生成32位16进制的随机请求ID
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:32 下午 2026/10/19
+func generateRequestID() string {
+	byteData := make([]byte, 16)
+	_, _ = rand.Read(byteData)
+	return hex.EncodeToString(byteData)
+}
+
+// isValidRequestID 请求中携带的请求ID只允许可见的ASCII字符, 避免污染日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:34 下午 2026/10/19
+func isValidRequestID(requestID string) bool {
+	if len(requestID) == 0 || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < '!' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
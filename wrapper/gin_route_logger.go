@@ -0,0 +1,133 @@
+// Package wrapper...
+//
+// Description : gin_route_logger 按路由分组或者路径前缀将日志写入不同的日志文件
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 1:35 上午
+package wrapper
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-developer/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// routeLogger 路由分组使用的日志实例, 第一次使用时创建, 所有请求共享
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:37 上午 2026/10/20
+type routeLogger struct {
+	pathPrefix     string                  // 路由分组或者路径前缀
+	splitConfig    *logger.RotateLogConfig // 日志切割的配置
+	once           sync.Once               // 保证只创建一次
+	loggerInstance *zap.Logger             // 创建的日志实例
+	callerLogger   *zap.Logger             // 跳过一层调用的日志实例
+	debugLogger    *zap.Logger             // 开启了Debug日志的请求使用的实例, 写入同一个日志文件
+}
+
+// WithRouteLogFile 设置路由分组或者路径前缀使用的日志文件, 多个前缀同时命中时使用最长的前缀
+//
+// 日志级别与编码同原有实例, 通过 NewGinWrapperFromLogger 创建时使用 logger.GetEncoder() 编码
+//
+// 开启了Debug日志或者缓存请求日志的请求, Debug日志同样写入该日志文件, 而不是 DebugLogger
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:40 上午 2026/10/20
+func WithRouteLogFile(pathPrefix string, splitConfig *logger.RotateLogConfig) SetGinWrapperOptionFunc {
+	return func(o *GinWrapperOption) {
+		if nil == splitConfig {
+			return
+		}
+		o.routeLoggerList = append(o.routeLoggerList, &routeLogger{
+			pathPrefix:  "/" + strings.Trim(strings.TrimSpace(pathPrefix), "/"),
+			splitConfig: splitConfig,
+		})
+	}
+}
+
+// withLoggerConfig 记录创建日志实例的配置, 创建路由分组的日志实例时使用
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:42 上午 2026/10/20
+func withLoggerConfig(consoleOutput bool, encoder zapcore.Encoder) SetGinWrapperOptionFunc {
+	return func(o *GinWrapperOption) {
+		o.consoleOutput = consoleOutput
+		o.encoder = encoder
+	}
+}
+
+// matchRouteLogger 查找请求命中的路由分组日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:45 上午 2026/10/20
+func (o *GinWrapperOption) matchRouteLogger(ctx *gin.Context) *routeLogger {
+	if nil == ctx || len(o.routeLoggerList) == 0 {
+		return nil
+	}
+	route := ctx.FullPath()
+	if len(route) == 0 {
+		route = ctx.Request.URL.Path
+	}
+	var matched *routeLogger
+	for _, rl := range o.routeLoggerList {
+		if !rl.match(route) {
+			continue
+		}
+		if nil == matched || len(rl.pathPrefix) > len(matched.pathPrefix) {
+			matched = rl
+		}
+	}
+	return matched
+}
+
+// match 路由是否属于该分组
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:47 上午 2026/10/20
+func (rl *routeLogger) match(route string) bool {
+	if rl.pathPrefix == "/" {
+		return true
+	}
+	return route == rl.pathPrefix || strings.HasPrefix(route, rl.pathPrefix+"/")
+}
+
+// getLogger 获取日志实例, 第一次使用时创建, 创建失败时通过 errorLogger 记录错误并返回nil, 使用原有实例记录
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:50 上午 2026/10/20
+func (rl *routeLogger) getLogger(o *GinWrapperOption, errorLogger *zap.Logger) (*zap.Logger, *zap.Logger) {
+	rl.once.Do(func() {
+		writer, err := logger.NewRotateWriter(rl.splitConfig)
+		if nil != err {
+			errorLogger.Error("路由分组的日志文件创建失败, 使用原有实例记录", zap.String("path_prefix", rl.pathPrefix), zap.Error(err))
+			return
+		}
+		encoder := o.encoder
+		if nil == encoder {
+			encoder = logger.GetEncoder()
+		}
+		writeSyncer := zapcore.Lock(zapcore.AddSync(writer))
+		coreList := []zapcore.Core{zapcore.NewCore(encoder, writeSyncer, o.levelEnabler)}
+		debugCoreList := []zapcore.Core{zapcore.NewCore(encoder, writeSyncer, zapcore.DebugLevel)}
+		if o.consoleOutput {
+			coreList = append(coreList, zapcore.NewCore(encoder, zapcore.AddSync(os.Stdout), o.levelEnabler))
+			debugCoreList = append(debugCoreList, zapcore.NewCore(encoder, zapcore.AddSync(os.Stdout), zapcore.DebugLevel))
+		}
+		rl.loggerInstance = zap.New(zapcore.NewTee(coreList...), zap.AddCaller())
+		rl.callerLogger = rl.loggerInstance.WithOptions(zap.AddCallerSkip(1))
+		rl.debugLogger = zap.New(zapcore.NewTee(debugCoreList...), zap.AddCaller())
+	})
+	return rl.loggerInstance, rl.callerLogger
+}
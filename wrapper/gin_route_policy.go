@@ -0,0 +1,178 @@
+// Package wrapper...
+//
+// Description : gin_route_policy 路由级别的日志策略 : 不记录、采样、最低日志级别、不记录body
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 10:05 下午
+package wrapper
+
+import (
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// routePolicyContextKey 命中的路由策略在gin上下文中的key
+	routePolicyContextKey = "__logger_route_policy"
+)
+
+// RoutePolicy 路由级别的日志策略, 可以在代码中设置, 也可以从json配置中解析
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:07 下午 2026/10/19
+type RoutePolicy struct {
+	Method          string         `json:"method"`            // 请求方法, 为空或者 * 时匹配全部方法
+	Route           string         `json:"route"`             // 路由, 匹配 ctx.FullPath(), 未注册的路由匹配请求路径, 以 * 结尾时按前缀匹配
+	Skip            bool           `json:"skip"`              // 不记录访问日志
+	SampleRate      uint64         `json:"sample_rate"`       // 采样, 每N个请求记录一条访问日志, 5xx 的请求始终记录, 0 / 1 - 全部记录
+	MinLevel        string         `json:"min_level"`         // 最低日志级别, 低于该级别的访问日志以及请求内的日志都不记录, 如 : warn
+	SkipBodyCapture bool           `json:"skip_body_capture"` // 不记录请求与响应的body
+	minLevel        *zapcore.Level // 解析后的最低日志级别
+	counter         *uint64        // 采样计数
+}
+
+// WithRoutePolicy 设置路由级别的日志策略, 多个策略同时命中时使用第一个
+//
+// 配置错误的策略(如无法解析的日志级别)不生效, 生成中间件时记录一条错误日志, 从配置中解析时使用 ParseRoutePolicyList 获取错误
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:12 下午 2026/10/19
+func WithRoutePolicy(policyList ...RoutePolicy) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		o.RoutePolicyList = append(o.RoutePolicyList, policyList...)
+	}
+}
+
+// ParseRoutePolicyList 从json配置中解析路由级别的日志策略
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:14 下午 2026/10/19
+func ParseRoutePolicyList(data []byte) ([]RoutePolicy, error) {
+	policyList := make([]RoutePolicy, 0)
+	if err := json.Unmarshal(data, &policyList); nil != err {
+		return nil, errors.Wrap(err, "路由日志策略解析失败")
+	}
+	for idx := range policyList {
+		if err := policyList[idx].init(); nil != err {
+			return nil, err
+		}
+	}
+	return policyList, nil
+}
+
+// init 解析日志级别, 初始化采样计数
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:16 下午 2026/10/19
+func (rp *RoutePolicy) init() error {
+	rp.Method = strings.ToUpper(strings.TrimSpace(rp.Method))
+	rp.Route = strings.TrimSpace(rp.Route)
+	if len(rp.MinLevel) > 0 {
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(rp.MinLevel)); nil != err {
+			return errors.Wrapf(err, "路由日志策略的日志级别错误, 路由 : %s, 日志级别 : %s", rp.Route, rp.MinLevel)
+		}
+		rp.minLevel = &level
+	}
+	rp.counter = new(uint64)
+	return nil
+}
+
+// initRoutePolicyList 初始化全部策略, 包括直接设置在 RoutePolicyList 中的策略, 配置错误的策略不生效, 返回对应的错误
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:40 上午 2026/10/21
+func (o *AccessLogOption) initRoutePolicyList() []error {
+	policyList := make([]RoutePolicy, 0, len(o.RoutePolicyList))
+	errList := make([]error, 0)
+	for _, policy := range o.RoutePolicyList {
+		if err := policy.init(); nil != err {
+			errList = append(errList, err)
+			continue
+		}
+		policyList = append(policyList, policy)
+	}
+	o.RoutePolicyList = policyList
+	return errList
+}
+
+// match 是否命中策略
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:19 下午 2026/10/19
+func (rp *RoutePolicy) match(method string, route string) bool {
+	if len(rp.Method) > 0 && rp.Method != "*" && rp.Method != method {
+		return false
+	}
+	if strings.HasSuffix(rp.Route, "*") {
+		return strings.HasPrefix(route, strings.TrimSuffix(rp.Route, "*"))
+	}
+	return rp.Route == route
+}
+
+// sampled 采样, 是否记录本次请求
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:21 下午 2026/10/19
+func (rp *RoutePolicy) sampled() bool {
+	if rp.SampleRate <= 1 {
+		return true
+	}
+	return (atomic.AddUint64(rp.counter, 1)-1)%rp.SampleRate == 0
+}
+
+// enabled 日志级别是否满足策略
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:23 下午 2026/10/19
+func (rp *RoutePolicy) enabled(level zapcore.Level) bool {
+	return nil == rp.minLevel || level >= *rp.minLevel
+}
+
+// matchRoutePolicy 查找请求命中的策略, 并记录在gin上下文中
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:25 下午 2026/10/19
+func (o *AccessLogOption) matchRoutePolicy(ctx *gin.Context) *RoutePolicy {
+	route := ctx.FullPath()
+	if len(route) == 0 {
+		route = ctx.Request.URL.Path
+	}
+	for idx := range o.RoutePolicyList {
+		if o.RoutePolicyList[idx].match(ctx.Request.Method, route) {
+			ctx.Set(routePolicyContextKey, &o.RoutePolicyList[idx])
+			return &o.RoutePolicyList[idx]
+		}
+	}
+	return nil
+}
+
+// getRoutePolicy 获取请求命中的策略
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:27 下午 2026/10/19
+func getRoutePolicy(ctx *gin.Context) *RoutePolicy {
+	if nil == ctx {
+		return nil
+	}
+	if policy, exist := ctx.Get(routePolicyContextKey); exist {
+		return policy.(*RoutePolicy)
+	}
+	return nil
+}
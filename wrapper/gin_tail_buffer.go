@@ -0,0 +1,211 @@
+// Package wrapper...
+//
+// Description : gin_tail_buffer 请求内的 Debug / Info 日志先缓存, 请求失败时才输出
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 12:20 上午
+package wrapper
+
+import (
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// tailBufferContextKey 请求日志缓存在gin上下文中的key
+	tailBufferContextKey = "__logger_tail_buffer"
+)
+
+// WithTailBuffer 缓存请求内的 Debug / Info 日志, 最多缓存 maxEntries 条, 超出时丢弃最早的日志
+//
+// 请求返回5xx或者记录了 Error 及以上级别的日志(包括panic)时输出缓存, 否则丢弃, 只记录访问日志.
+// 缓存 Debug 日志需要配置 WithDebugLogger, 通过 NewGinWrapperLogger 创建时默认已配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:22 上午 2026/10/20
+func WithTailBuffer(maxEntries int) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		if maxEntries <= 0 {
+			return
+		}
+		o.TailBufferSize = maxEntries
+	}
+}
+
+// tailEntry 缓存的一条日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:24 上午 2026/10/20
+type tailEntry struct {
+	core      zapcore.Core    // 输出日志的core, 包含 With 设置的字段
+	entry     zapcore.Entry   // 日志
+	fieldList []zapcore.Field // 日志的字段
+}
+
+// tailBuffer 一个请求内缓存的日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:26 上午 2026/10/20
+type tailBuffer struct {
+	lock         sync.Mutex
+	maxEntries   int         // 最多缓存的日志数量
+	entryList    []tailEntry // 缓存的日志
+	droppedCount int         // 超出数量被丢弃的日志
+	finished     bool        // 缓存已经输出或者丢弃, 之后的日志直接输出
+}
+
+// newTailBuffer 生成请求日志缓存, 并记录在gin上下文中
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:28 上午 2026/10/20
+func newTailBuffer(ctx *gin.Context, maxEntries int) *tailBuffer {
+	tb := &tailBuffer{
+		maxEntries: maxEntries,
+		entryList:  make([]tailEntry, 0),
+	}
+	ctx.Set(tailBufferContextKey, tb)
+	return tb
+}
+
+// getTailBuffer 获取请求日志缓存
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:29 上午 2026/10/20
+func getTailBuffer(ctx *gin.Context) *tailBuffer {
+	if nil == ctx {
+		return nil
+	}
+	if tb, exist := ctx.Get(tailBufferContextKey); exist {
+		return tb.(*tailBuffer)
+	}
+	return nil
+}
+
+// add 缓存一条日志, 已经输出或者丢弃时返回false
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:31 上午 2026/10/20
+func (tb *tailBuffer) add(entry tailEntry) bool {
+	tb.lock.Lock()
+	defer tb.lock.Unlock()
+	if tb.finished {
+		return false
+	}
+	if len(tb.entryList) >= tb.maxEntries {
+		tb.entryList = tb.entryList[1:]
+		tb.droppedCount++
+	}
+	tb.entryList = append(tb.entryList, entry)
+	return true
+}
+
+// isFinished 缓存是否已经输出或者丢弃
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:32 上午 2026/10/20
+func (tb *tailBuffer) isFinished() bool {
+	tb.lock.Lock()
+	defer tb.lock.Unlock()
+	return tb.finished
+}
+
+// finish 结束缓存, flush 为true时输出缓存的日志, 否则丢弃, 返回输出的日志数量与丢弃的日志数量
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:34 上午 2026/10/20
+func (tb *tailBuffer) finish(flush bool) (int, int) {
+	tb.lock.Lock()
+	if tb.finished {
+		tb.lock.Unlock()
+		return 0, 0
+	}
+	tb.finished = true
+	entryList, droppedCount := tb.entryList, tb.droppedCount
+	tb.entryList = nil
+	tb.lock.Unlock()
+
+	if !flush {
+		return 0, droppedCount + len(entryList)
+	}
+	for _, item := range entryList {
+		if ce := item.core.Check(item.entry, nil); nil != ce {
+			ce.Write(item.fieldList...)
+		}
+	}
+	return len(entryList), droppedCount
+}
+
+// withTailCore 生成缓存日志的实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:36 上午 2026/10/20
+func withTailCore(l *zap.Logger, tb *tailBuffer) *zap.Logger {
+	return l.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &tailCore{Core: core, tailBuffer: tb}
+	}))
+}
+
+// tailCore 缓存 Debug / Info 日志的core, 记录 Error 及以上级别的日志时先输出缓存
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:38 上午 2026/10/20
+type tailCore struct {
+	zapcore.Core
+	tailBuffer *tailBuffer // 请求日志缓存
+}
+
+// With ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:39 上午 2026/10/20
+func (c *tailCore) With(fieldList []zapcore.Field) zapcore.Core {
+	return &tailCore{Core: c.Core.With(fieldList), tailBuffer: c.tailBuffer}
+}
+
+// Check ...
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:41 上午 2026/10/20
+func (c *tailCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Core.Enabled(ent.Level) || c.tailBuffer.isFinished() {
+		return c.Core.Check(ent, ce)
+	}
+	if ent.Level >= zapcore.ErrorLevel {
+		c.tailBuffer.finish(true)
+	}
+	if ent.Level >= zapcore.WarnLevel {
+		return c.Core.Check(ent, ce)
+	}
+	return ce.AddCore(ent, c)
+}
+
+// Write 缓存日志, 缓存已经结束时直接输出
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:43 上午 2026/10/20
+func (c *tailCore) Write(ent zapcore.Entry, fieldList []zapcore.Field) error {
+	if c.tailBuffer.add(tailEntry{core: c.Core, entry: ent, fieldList: fieldList}) {
+		return nil
+	}
+	if ce := c.Core.Check(ent, nil); nil != ce {
+		ce.Write(fieldList...)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// Package wrapper...
+//
+// Description : gin_text_log 访问日志使用 Apache / Nginx 的 Common / Combined 文本格式记录
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 2:10 上午
+package wrapper
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-developer/logger"
+)
+
+// TextLogFormat 文本访问日志的格式
+type TextLogFormat uint
+
+const (
+	// TextLogFormatCommon Common Log Format
+	TextLogFormatCommon = TextLogFormat(1)
+	// TextLogFormatCombined Combined Log Format, 在 Common 的基础上增加 Referer 与 User-Agent
+	TextLogFormatCombined = TextLogFormat(2)
+)
+
+// TextLogField 文本访问日志末尾追加的字段
+type TextLogField uint
+
+const (
+	// TextLogFieldRequestID 请求ID, 需要使用请求ID中间件
+	TextLogFieldRequestID = TextLogField(1)
+	// TextLogFieldLatency 请求耗时, 单位秒, 精确到毫秒, 同 nginx 的 $request_time
+	TextLogFieldLatency = TextLogField(2)
+)
+
+const (
+	// textLogTimeFormat 文本访问日志的时间格式
+	textLogTimeFormat = "02/Jan/2006:15:04:05 -0700"
+	// textLogEmptyValue 文本访问日志的空值
+	textLogEmptyValue = "-"
+)
+
+// WithTextLog 访问日志使用文本格式写入指定的writer, 不再记录json格式的访问日志
+//
+// 请求的错误、AddField 追加的字段、合并模式的日志等文本格式无法记录的内容, 仍然记录一条json日志
+//
+// 写入按时间切割的日志文件时, 使用 WithTextLogFile
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:14 上午 2026/10/20
+func WithTextLog(format TextLogFormat, writer io.Writer, extraFieldList ...TextLogField) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		if nil == writer || (format != TextLogFormatCommon && format != TextLogFormatCombined) {
+			return
+		}
+		o.TextLogFormat = format
+		o.TextLogWriter = writer
+		o.TextLogFieldList = extraFieldList
+	}
+}
+
+// WithTextLogFile 访问日志使用文本格式写入单独的按时间切割的日志文件, 不再记录json格式的访问日志
+//
+// 日志文件创建失败时, 生成中间件时记录一条错误日志, 仍然记录json格式的访问日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:30 上午 2026/10/21
+func WithTextLogFile(format TextLogFormat, splitConfig *logger.RotateLogConfig, extraFieldList ...TextLogField) SetAccessLogOptionFunc {
+	return func(o *AccessLogOption) {
+		if nil == splitConfig {
+			return
+		}
+		writer, err := logger.NewRotateWriter(splitConfig)
+		if nil != err {
+			o.textLogFileErr = err
+			return
+		}
+		WithTextLog(format, writer, extraFieldList...)(o)
+	}
+}
+
+// writeTextLog 写入一行文本访问日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:18 上午 2026/10/20
+func (o *AccessLogOption) writeTextLog(ctx *gin.Context, start time.Time, latency time.Duration, status int) {
+	req := ctx.Request
+	user, _, _ := req.BasicAuth()
+	if len(user) == 0 {
+		user = textLogEmptyValue
+	}
+	bytesOut := textLogEmptyValue
+	if size := responseSize(ctx.Writer); size > 0 {
+		bytesOut = strconv.Itoa(size)
+	}
+	requestURL := *req.URL
+	requestURL.RawQuery = getDebugLogOption(ctx).redactQuery(requestURL.RawQuery)
+	buf := &bytes.Buffer{}
+	_, _ = fmt.Fprintf(buf, "%s - %s [%s] \"%s %s %s\" %d %s",
+		o.clientIP(req),
+		escapeTextLog(user),
+		start.Format(textLogTimeFormat),
+		escapeTextLog(req.Method),
+		escapeTextLog(requestURL.RequestURI()),
+		escapeTextLog(req.Proto),
+		status,
+		bytesOut,
+	)
+	if o.TextLogFormat == TextLogFormatCombined {
+		_, _ = fmt.Fprintf(buf, " \"%s\" \"%s\"", textLogValue(req.Referer()), textLogValue(req.UserAgent()))
+	}
+	for _, field := range o.TextLogFieldList {
+		switch field {
+		case TextLogFieldRequestID:
+			_, _ = fmt.Fprintf(buf, " \"%s\"", textLogValue(GetRequestID(ctx)))
+		case TextLogFieldLatency:
+			_, _ = fmt.Fprintf(buf, " %.3f", latency.Seconds())
+		}
+	}
+	buf.WriteByte('\n')
+	// 一次写入一整行, 避免并发写入时日志交错
+	_, _ = o.TextLogWriter.Write(buf.Bytes())
+}
+
+// textLogValue 转义文本访问日志中的值, 空值使用 -
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:22 上午 2026/10/20
+func textLogValue(value string) string {
+	if len(value) == 0 {
+		return textLogEmptyValue
+	}
+	return escapeTextLog(value)
+}
+
+// escapeTextLog 转义双引号、反斜杠以及不可见字符, 同 nginx 的处理方式
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:24 上午 2026/10/20
+func escapeTextLog(value string) string {
+	buf := &bytes.Buffer{}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '"' || c == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case c < ' ' || c > '~':
+			_, _ = fmt.Fprintf(buf, "\\x%02X", c)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,51 @@
+// Package wrapper...
+//
+// Description : gin_trace 解析请求中的链路追踪header, 同一个请求的所有日志都会携带 trace_id、span_id、sampled
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-20 5:40 上午
+package wrapper
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-developer/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// traceContextKey 链路追踪字段在gin上下文中的key
+	traceContextKey = "__logger_trace"
+)
+
+// TraceMiddleware 链路追踪中间件, 解析 W3C traceparent (可选 b3) header, 记录在gin上下文以及 ctx.Request.Context() 中
+//
+// 与 logger.TraceHandler 一致, 通过 logger.Ctx(ctx.Request.Context()) 获取的日志实例会携带链路追踪字段,
+// 通过 logger.TraceFromContext 获取链路追踪信息
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:43 上午 2026/10/20
+func TraceMiddleware(option ...logger.SetTraceOptionFunc) gin.HandlerFunc {
+	o := logger.NewTraceOption(option...)
+	return func(ctx *gin.Context) {
+		if tc, ok := o.Extract(ctx.Request.Header); ok {
+			fieldList := o.FieldList(tc)
+			ctx.Set(traceContextKey, fieldList)
+			ctx.Request = ctx.Request.WithContext(logger.WithContext(logger.NewTraceContext(ctx.Request.Context(), tc), fieldList...))
+		}
+		ctx.Next()
+	}
+}
+
+// getTraceFieldList 获取请求的链路追踪字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:45 上午 2026/10/20
+func getTraceFieldList(ctx *gin.Context) []zap.Field {
+	if fieldList, exist := ctx.Get(traceContextKey); exist {
+		return fieldList.([]zap.Field)
+	}
+	return nil
+}
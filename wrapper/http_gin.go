@@ -8,7 +8,8 @@
 package wrapper
 
 import (
-	"encoding/json"
+	"reflect"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-developer/logger"
@@ -21,19 +22,51 @@ import (
 // Author : go_developer@163.com<张德满>
 //
 // Date : 3:45 下午 2021/1/3
-func NewGinWrapperLogger(loggerLevel zapcore.Level, consoleOutput bool, encoder zapcore.Encoder, splitConfig *logger.RotateLogConfig, extractFieldList []string) (*GinWrapper, error) {
+func NewGinWrapperLogger(loggerLevel zapcore.Level, consoleOutput bool, encoder zapcore.Encoder, splitConfig *logger.RotateLogConfig, extractFieldList []string, option ...SetGinWrapperOptionFunc) (*GinWrapper, error) {
 	var (
 		err error
 		l   *zap.Logger
 	)
-	if l, err = logger.NewLogger(loggerLevel, consoleOutput, encoder, splitConfig); nil != err {
+	// 按Debug级别创建, 开启了Debug日志的请求使用原始实例, 其余请求按配置的级别过滤
+	if l, err = logger.NewLogger(zapcore.DebugLevel, consoleOutput, encoder, splitConfig); nil != err {
 		return nil, err
 	}
+	option = append([]SetGinWrapperOptionFunc{WithDebugLogger(l), withLoggerConfig(consoleOutput, encoder)}, option...)
+	return NewGinWrapperFromLogger(withMinLevel(l, loggerLevel), extractFieldList, option...), nil
+}
 
+// NewGinWrapperFromLogger 使用已有的zap日志实例记录gin框架的日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:50 下午 2026/10/19
+func NewGinWrapperFromLogger(l *zap.Logger, extractFieldList []string, option ...SetGinWrapperOptionFunc) *GinWrapper {
+	o := &GinWrapperOption{
+		ExtractFieldList:   make([]ExtractField, 0, len(extractFieldList)),
+		FieldExtractorList: make([]FieldExtractor, 0),
+		routeLoggerList:    make([]*routeLogger, 0),
+		levelEnabler:       l.Core(),
+	}
+	// 兼容原有的抽取字段, 从gin上下文中抽取
+	for _, key := range extractFieldList {
+		o.ExtractFieldList = append(o.ExtractFieldList, ExtractField{Source: ExtractSourceContext, Key: key})
+	}
+	for _, f := range option {
+		f(o)
+	}
+	o.contextKeyList = []string{requestIDContextKey, traceContextKey}
+	for _, extractField := range o.ExtractFieldList {
+		if extractField.Source == ExtractSourceContext {
+			o.contextKeyList = append(o.contextKeyList, extractField.Key)
+		}
+	}
+	callerLogger := l.WithOptions(zap.AddCallerSkip(1))
 	return &GinWrapper{
-		loggerInstance:   l,
-		extractFieldList: extractFieldList,
-	}, nil
+		loggerInstance: l,
+		callerLogger:   callerLogger,
+		sugarLogger:    callerLogger.Sugar(),
+		option:         o,
+	}
 }
 
 // GinWrapper 包装gin实例
@@ -42,21 +75,121 @@ func NewGinWrapperLogger(loggerLevel zapcore.Level, consoleOutput bool, encoder
 //
 // Date : 3:59 下午 2021/1/3
 type GinWrapper struct {
-	loggerInstance   *zap.Logger  // zap 的日志实例
-	extractFieldList []string     // 从gin中抽取的字段
-	ginCtx           *gin.Context // gin 实例
+	loggerInstance *zap.Logger        // zap 的日志实例
+	callerLogger   *zap.Logger        // 跳过一层调用的zap日志实例, 保证日志记录的文件是调用 GinWrapper 的代码
+	sugarLogger    *zap.SugaredLogger // 格式化日志使用的实例
+	fieldList      []zap.Field        // With 设置的字段, 生成Debug日志实例时使用
+	fieldCache     *requestFieldCache // 请求中抽取的字段, 每个请求只抽取一次
+	option         *GinWrapperOption  // 配置, 所有请求共享
+	ginCtx         *gin.Context       // gin 实例
 }
 
+// GinWrapperOption gin日志实例的配置
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:34 下午 2026/10/19
+type GinWrapperOption struct {
+	ExtractFieldList   []ExtractField       // 从请求中抽取的字段
+	FieldExtractorList []FieldExtractor     // 自定义抽取字段的方法
+	DebugLogger        *zap.Logger          // 开启了Debug日志的请求使用的实例
+	routeLoggerList    []*routeLogger       // 路由分组使用的日志实例
+	levelEnabler       zapcore.LevelEnabler // 原有实例的日志级别, 路由分组的日志实例使用
+	consoleOutput      bool                 // 是否输出到控制台, 路由分组的日志实例使用
+	encoder            zapcore.Encoder      // 日志编码, 路由分组的日志实例使用
+	contextKeyList     []string             // 抽取字段依赖的gin上下文key, 值变化时重新抽取
+}
+
+// SetGinWrapperOptionFunc 设置gin日志实例的配置
+type SetGinWrapperOptionFunc func(o *GinWrapperOption)
+
 // GetLogger 为每一次请求生成不同的日志实例,包含独立的gin上下文
 //
 // Author : go_developer@163.com<张德满>
 //
 // Date : 4:02 下午 2021/1/3
 func (gw *GinWrapper) GetLogger(ginCtx *gin.Context) *GinWrapper {
+	requestLogger := &GinWrapper{
+		loggerInstance: gw.loggerInstance,
+		callerLogger:   gw.callerLogger,
+		sugarLogger:    gw.sugarLogger,
+		fieldList:      gw.fieldList,
+		fieldCache:     newRequestFieldCache(ginCtx),
+		option:         gw.option,
+		ginCtx:         ginCtx,
+	}
+	// 路由分组设置了单独的日志文件, Debug日志同样写入该文件
+	debugLogger := gw.option.DebugLogger
+	if rl := gw.option.matchRouteLogger(ginCtx); nil != rl {
+		if loggerInstance, callerLogger := rl.getLogger(gw.option, gw.loggerInstance); nil != loggerInstance {
+			requestLogger.loggerInstance = loggerInstance.With(gw.fieldList...)
+			requestLogger.callerLogger = callerLogger.With(gw.fieldList...)
+			requestLogger.sugarLogger = requestLogger.callerLogger.Sugar()
+			if nil != debugLogger {
+				debugLogger = rl.debugLogger
+			}
+		}
+	}
+	tb := getTailBuffer(ginCtx)
+	if (IsDebugLog(ginCtx) || nil != tb) && nil != debugLogger {
+		// 请求开启了Debug日志或者缓存请求日志, 额外记录Debug级别的日志
+		requestLogger.loggerInstance = gw.withDebugCore(requestLogger.loggerInstance, debugLogger)
+		requestLogger.callerLogger = gw.withDebugCore(requestLogger.callerLogger, debugLogger)
+		requestLogger.sugarLogger = requestLogger.callerLogger.Sugar()
+	} else if policy := getRoutePolicy(ginCtx); nil != policy && nil != policy.minLevel {
+		// 路由策略设置了最低日志级别, 请求内的日志按该级别过滤
+		requestLogger.loggerInstance = withMinLevel(requestLogger.loggerInstance, *policy.minLevel)
+		requestLogger.callerLogger = withMinLevel(requestLogger.callerLogger, *policy.minLevel)
+		requestLogger.sugarLogger = requestLogger.callerLogger.Sugar()
+	}
+	// 缓存请求日志, 请求失败时才输出
+	if nil != tb {
+		requestLogger.loggerInstance = withTailCore(requestLogger.loggerInstance, tb)
+		requestLogger.callerLogger = withTailCore(requestLogger.callerLogger, tb)
+		requestLogger.sugarLogger = requestLogger.callerLogger.Sugar()
+	}
+	// 合并模式下, Debug / Info 日志合并到访问日志中, 优先于缓存请求日志
+	if cl := getCanonicalLog(ginCtx); nil != cl && cl.merge {
+		requestLogger.loggerInstance = withCanonicalCore(requestLogger.loggerInstance, cl)
+		requestLogger.callerLogger = withCanonicalCore(requestLogger.callerLogger, cl)
+		requestLogger.sugarLogger = requestLogger.callerLogger.Sugar()
+	}
+	return requestLogger
+}
+
+// withMinLevel 生成只记录不低于指定级别日志的实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:32 下午 2026/10/19
+func withMinLevel(l *zap.Logger, minLevel zapcore.Level) *zap.Logger {
+	return l.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		filterCore, err := zapcore.NewIncreaseLevelCore(core, zap.LevelEnablerFunc(func(level zapcore.Level) bool {
+			return level >= minLevel && core.Enabled(level)
+		}))
+		if nil != err {
+			return core
+		}
+		return filterCore
+	}))
+}
+
+// With 生成携带指定字段的子日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:53 下午 2026/10/19
+func (gw *GinWrapper) With(field ...zap.Field) *GinWrapper {
+	callerLogger := gw.callerLogger.With(field...)
+	fieldList := make([]zap.Field, 0, len(gw.fieldList)+len(field))
 	return &GinWrapper{
-		loggerInstance:   gw.loggerInstance,
-		extractFieldList: gw.extractFieldList,
-		ginCtx:           ginCtx,
+		loggerInstance: gw.loggerInstance.With(field...),
+		callerLogger:   callerLogger,
+		sugarLogger:    callerLogger.Sugar(),
+		fieldList:      append(append(fieldList, gw.fieldList...), field...),
+		fieldCache:     newRequestFieldCache(gw.ginCtx),
+		option:         gw.option,
+		ginCtx:         gw.ginCtx,
 	}
 }
 
@@ -70,25 +203,161 @@ func (gw *GinWrapper) formatFieldList(inputFieldList []zap.Field) []zap.Field {
 		inputFieldList = make([]zap.Field, 0)
 	}
 	if nil != gw.ginCtx {
+		// 请求ID中间件生成的请求ID自动记录
+		if requestID := GetRequestID(gw.ginCtx); len(requestID) > 0 {
+			inputFieldList = append(inputFieldList, zap.String(RequestIDField, requestID))
+		}
+		// 链路追踪中间件解析的字段自动记录
+		inputFieldList = append(inputFieldList, getTraceFieldList(gw.ginCtx)...)
 		// 自动扩充抽取字段,字段不存在的话,忽略掉
-		for _, extractField := range gw.extractFieldList {
-			if v, exist := gw.ginCtx.Get(extractField); exist {
-				byteData, _ := json.Marshal(v)
-				inputFieldList = append(inputFieldList, zap.String(extractField, string(byteData)))
+		for _, extractField := range gw.option.ExtractFieldList {
+			if f, exist := extractField.extract(gw.ginCtx); exist {
+				inputFieldList = append(inputFieldList, f)
 			}
 		}
+		for _, extractor := range gw.option.FieldExtractorList {
+			inputFieldList = append(inputFieldList, extractor(gw.ginCtx)...)
+		}
 	}
 	return inputFieldList
 }
 
+// requestFieldCache 请求中抽取的字段, 通过 With 设置到日志实例中, 避免每次记录日志都重新抽取
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:20 上午 2026/10/20
+type requestFieldCache struct {
+	lock         sync.Mutex
+	valueList    []interface{}      // 抽取字段时依赖的gin上下文数据的值, 值变化时重新抽取
+	callerLogger *zap.Logger        // 携带抽取字段的日志实例
+	sugarLogger  *zap.SugaredLogger // 携带抽取字段的格式化日志实例
+}
+
+// newRequestFieldCache 生成请求字段的缓存, 没有gin上下文时不需要缓存
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:22 上午 2026/10/20
+func newRequestFieldCache(ginCtx *gin.Context) *requestFieldCache {
+	if nil == ginCtx {
+		return nil
+	}
+	return &requestFieldCache{}
+}
+
+// isExpired gin上下文中的数据与抽取字段时不一致
+//
+// 只通过 ctx.Get 读取抽取字段依赖的key, ctx.Keys 可能被其他协程通过 ctx.Set 并发修改, 不能直接遍历
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:50 上午 2026/10/21
+func (fc *requestFieldCache) isExpired(ginCtx *gin.Context, keyList []string) bool {
+	if nil == fc.callerLogger {
+		return true
+	}
+	for idx, key := range keyList {
+		value, _ := ginCtx.Get(key)
+		if !isSameValue(fc.valueList[idx], value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSameValue 两个值是否相同, map、切片、函数只比较是否同一个实例, 其他无法直接比较的值比较内容
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:52 上午 2026/10/21
+func isSameValue(a interface{}, b interface{}) bool {
+	if nil == a || nil == b {
+		return a == b
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Type() != vb.Type() {
+		return false
+	}
+	switch va.Kind() {
+	case reflect.Map, reflect.Func:
+		return va.Pointer() == vb.Pointer()
+	case reflect.Slice:
+		return va.Pointer() == vb.Pointer() && va.Len() == vb.Len()
+	}
+	if !va.Comparable() {
+		return reflect.DeepEqual(a, b)
+	}
+	return a == b
+}
+
+// loadFieldCache 获取携带请求字段的日志实例, 第一次使用或者gin上下文中的数据变化时重新抽取
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:25 上午 2026/10/20
+func (gw *GinWrapper) loadFieldCache() (*zap.Logger, *zap.SugaredLogger) {
+	if nil == gw.fieldCache {
+		return gw.callerLogger, gw.sugarLogger
+	}
+	fc := gw.fieldCache
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	if fc.isExpired(gw.ginCtx, gw.option.contextKeyList) {
+		fc.valueList = make([]interface{}, len(gw.option.contextKeyList))
+		for idx, key := range gw.option.contextKeyList {
+			fc.valueList[idx], _ = gw.ginCtx.Get(key)
+		}
+		fc.callerLogger = gw.callerLogger.With(gw.formatFieldList(nil)...)
+		fc.sugarLogger = fc.callerLogger.Sugar()
+	}
+	return fc.callerLogger, fc.sugarLogger
+}
+
+// Refresh 下一次记录日志时重新抽取请求字段
+//
+// 请求ID、链路追踪以及从gin上下文中抽取的数据重新设置时会自动重新抽取, 直接修改已设置的map、切片内部的数据,
+// 或者自定义的抽取方法读取了其他数据(如gin上下文中的其他数据、修改后的请求header)时, 需要手动调用
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:56 上午 2026/10/21
+func (gw *GinWrapper) Refresh() {
+	if nil == gw.fieldCache {
+		return
+	}
+	gw.fieldCache.lock.Lock()
+	defer gw.fieldCache.lock.Unlock()
+	gw.fieldCache.callerLogger = nil
+}
+
+// fieldLogger 携带请求字段的日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:27 上午 2026/10/20
+func (gw *GinWrapper) fieldLogger() *zap.Logger {
+	l, _ := gw.loadFieldCache()
+	return l
+}
+
+// fieldSugarLogger 携带请求字段的格式化日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:28 上午 2026/10/20
+func (gw *GinWrapper) fieldSugarLogger() *zap.SugaredLogger {
+	_, sugarLogger := gw.loadFieldCache()
+	return sugarLogger
+}
+
 // Debug 日志
 //
 // Author : go_developer@163.com<张德满>
 //
 // Date : 4:14 下午 2021/1/3
 func (gw *GinWrapper) Debug(msg string, field ...zap.Field) {
-	fieldList := gw.formatFieldList(field)
-	gw.loggerInstance.Debug(msg, fieldList...)
+	gw.fieldLogger().Debug(msg, field...)
 }
 
 // Info 日志
@@ -97,8 +366,7 @@ func (gw *GinWrapper) Debug(msg string, field ...zap.Field) {
 //
 // Date : 4:28 下午 2021/1/3
 func (gw *GinWrapper) Info(msg string, field ...zap.Field) {
-	fieldList := gw.formatFieldList(field)
-	gw.loggerInstance.Info(msg, fieldList...)
+	gw.fieldLogger().Info(msg, field...)
 }
 
 // Warn 日志
@@ -107,8 +375,7 @@ func (gw *GinWrapper) Info(msg string, field ...zap.Field) {
 //
 // Date : 4:29 下午 2021/1/3
 func (gw *GinWrapper) Warn(msg string, field ...zap.Field) {
-	fieldList := gw.formatFieldList(field)
-	gw.loggerInstance.Warn(msg, fieldList...)
+	gw.fieldLogger().Warn(msg, field...)
 }
 
 // Error 日志
@@ -117,8 +384,7 @@ func (gw *GinWrapper) Warn(msg string, field ...zap.Field) {
 //
 // Date : 4:29 下午 2021/1/3
 func (gw *GinWrapper) Error(msg string, field ...zap.Field) {
-	fieldList := gw.formatFieldList(field)
-	gw.loggerInstance.Error(msg, fieldList...)
+	gw.fieldLogger().Error(msg, field...)
 }
 
 // Panic 日志
@@ -127,8 +393,7 @@ func (gw *GinWrapper) Error(msg string, field ...zap.Field) {
 //
 // Date : 4:29 下午 2021/1/3
 func (gw *GinWrapper) Panic(msg string, field ...zap.Field) {
-	fieldList := gw.formatFieldList(field)
-	gw.loggerInstance.Panic(msg, fieldList...)
+	gw.fieldLogger().Panic(msg, field...)
 }
 
 // DPanic 日志
@@ -137,8 +402,25 @@ func (gw *GinWrapper) Panic(msg string, field ...zap.Field) {
 //
 // Date : 4:30 下午 2021/1/3
 func (gw *GinWrapper) DPanic(msg string, field ...zap.Field) {
-	fieldList := gw.formatFieldList(field)
-	gw.loggerInstance.DPanic(msg, fieldList...)
+	gw.fieldLogger().DPanic(msg, field...)
+}
+
+// Fatal 日志, 记录之后进程退出
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:56 下午 2026/10/19
+func (gw *GinWrapper) Fatal(msg string, field ...zap.Field) {
+	gw.fieldLogger().Fatal(msg, field...)
+}
+
+// Sync 将缓冲区的日志写入
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:57 下午 2026/10/19
+func (gw *GinWrapper) Sync() error {
+	return gw.loggerInstance.Sync()
 }
 
 // GetZapLoggerInstance 获取zap日志实例
@@ -0,0 +1,150 @@
+// Package wrapper...
+//
+// Description : http_gin_sugar gin日志实例的格式化日志方法, 与 zap.SugaredLogger 的用法一致
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 9:00 下午
+package wrapper
+
+import (
+	"fmt"
+)
+
+// Debugf 格式化日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:02 下午 2026/10/19
+func (gw *GinWrapper) Debugf(template string, args ...interface{}) {
+	gw.fieldLogger().Debug(formatMessage(template, args))
+}
+
+// Infof 格式化日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:03 下午 2026/10/19
+func (gw *GinWrapper) Infof(template string, args ...interface{}) {
+	gw.fieldLogger().Info(formatMessage(template, args))
+}
+
+// Warnf 格式化日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:04 下午 2026/10/19
+func (gw *GinWrapper) Warnf(template string, args ...interface{}) {
+	gw.fieldLogger().Warn(formatMessage(template, args))
+}
+
+// Errorf 格式化日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:05 下午 2026/10/19
+func (gw *GinWrapper) Errorf(template string, args ...interface{}) {
+	gw.fieldLogger().Error(formatMessage(template, args))
+}
+
+// DPanicf 格式化日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:06 下午 2026/10/19
+func (gw *GinWrapper) DPanicf(template string, args ...interface{}) {
+	gw.fieldLogger().DPanic(formatMessage(template, args))
+}
+
+// Panicf 格式化日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:07 下午 2026/10/19
+func (gw *GinWrapper) Panicf(template string, args ...interface{}) {
+	gw.fieldLogger().Panic(formatMessage(template, args))
+}
+
+// Fatalf 格式化日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:08 下午 2026/10/19
+func (gw *GinWrapper) Fatalf(template string, args ...interface{}) {
+	gw.fieldLogger().Fatal(formatMessage(template, args))
+}
+
+// Debugw 使用键值对记录字段的日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:09 下午 2026/10/19
+func (gw *GinWrapper) Debugw(msg string, keysAndValues ...interface{}) {
+	gw.fieldSugarLogger().Debugw(msg, keysAndValues...)
+}
+
+// Infow 使用键值对记录字段的日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:10 下午 2026/10/19
+func (gw *GinWrapper) Infow(msg string, keysAndValues ...interface{}) {
+	gw.fieldSugarLogger().Infow(msg, keysAndValues...)
+}
+
+// Warnw 使用键值对记录字段的日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:11 下午 2026/10/19
+func (gw *GinWrapper) Warnw(msg string, keysAndValues ...interface{}) {
+	gw.fieldSugarLogger().Warnw(msg, keysAndValues...)
+}
+
+// Errorw 使用键值对记录字段的日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:12 下午 2026/10/19
+func (gw *GinWrapper) Errorw(msg string, keysAndValues ...interface{}) {
+	gw.fieldSugarLogger().Errorw(msg, keysAndValues...)
+}
+
+// DPanicw 使用键值对记录字段的日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:13 下午 2026/10/19
+func (gw *GinWrapper) DPanicw(msg string, keysAndValues ...interface{}) {
+	gw.fieldSugarLogger().DPanicw(msg, keysAndValues...)
+}
+
+// Panicw 使用键值对记录字段的日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:14 下午 2026/10/19
+func (gw *GinWrapper) Panicw(msg string, keysAndValues ...interface{}) {
+	gw.fieldSugarLogger().Panicw(msg, keysAndValues...)
+}
+
+// Fatalw 使用键值对记录字段的日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:15 下午 2026/10/19
+func (gw *GinWrapper) Fatalw(msg string, keysAndValues ...interface{}) {
+	gw.fieldSugarLogger().Fatalw(msg, keysAndValues...)
+}
+
+// formatMessage 格式化日志内容, 没有参数时直接使用模板
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:17 下午 2026/10/19
+func formatMessage(template string, args []interface{}) string {
+	if len(args) == 0 {
+		return template
+	}
+	return fmt.Sprintf(template, args...)
+}
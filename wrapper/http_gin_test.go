@@ -0,0 +1,907 @@
+// Package wrapper...
+//
+// Description : http_gin_test gin日志包装的单元测试
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2026-10-19 5:55 下午
+package wrapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-developer/logger"
+	pkgerrors "github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestGinWrapper 生成写入内存的gin日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:56 下午 2026/10/19
+func newTestGinWrapper(extractFieldList []string, option ...SetGinWrapperOptionFunc) (*GinWrapper, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	core := zapcore.NewCore(logger.GetEncoder(), zapcore.AddSync(buf), zapcore.DebugLevel)
+	return NewGinWrapperFromLogger(zap.New(core, zap.AddCaller()), extractFieldList, option...), buf
+}
+
+// decodeTestLineList 解析多行json日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:58 下午 2026/10/19
+func decodeTestLineList(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if len(line) == 0 {
+			continue
+		}
+		data := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(line), &data); nil != err {
+			t.Fatalf("日志不是合法的json : %s, err : %v", line, err)
+		}
+		result = append(result, data)
+	}
+	return result
+}
+
+// Test_AccessLogMiddleware 测试访问日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:00 下午 2026/10/19
+func Test_AccessLogMiddleware(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	router := gin.New()
+	router.Use(gw.AccessLogMiddleware(WithTrustedProxyList("10.0.0.0/8"), WithSlowThreshold(10*time.Millisecond)))
+	router.GET("/user/:id", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, "ok")
+	})
+	router.GET("/slow", func(ctx *gin.Context) {
+		time.Sleep(20 * time.Millisecond)
+	})
+	router.GET("/fail", func(ctx *gin.Context) {
+		ctx.Status(http.StatusBadGateway)
+	})
+
+	for _, path := range []string{"/user/1", "/slow", "/fail"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", "1.2.3.4, 10.0.0.2")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	lineList := decodeTestLineList(t, buf)
+	if len(lineList) != 3 {
+		t.Fatalf("访问日志数量错误 : %s", buf.String())
+	}
+	if lineList[0]["route"] != "/user/:id" || lineList[0]["client_ip"] != "1.2.3.4" || lineList[0]["bytes_out"] != float64(2) || lineList[0]["level"] != "INFO" {
+		t.Fatalf("访问日志错误 : %v", lineList[0])
+	}
+	if lineList[1]["level"] != "WARN" || lineList[2]["level"] != "ERROR" {
+		t.Fatalf("访问日志级别错误 : %s", buf.String())
+	}
+}
+
+// Test_RequestIDMiddleware 测试请求ID
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 6:40 下午 2026/10/19
+func Test_RequestIDMiddleware(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(WithRequestIDHeader("X-Trace")))
+	router.GET("/", func(ctx *gin.Context) {
+		gw.GetLogger(ctx).Info("handler")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Trace", "abc")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Header().Get("X-Trace") != "abc" || decodeTestLineList(t, buf)[0][RequestIDField] != "abc" {
+		t.Fatalf("请求ID错误 : %s", buf.String())
+	}
+
+	buf.Reset()
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	if requestID := resp.Header().Get("X-Trace"); len(requestID) != 32 || decodeTestLineList(t, buf)[0][RequestIDField] != requestID {
+		t.Fatalf("生成请求ID错误 : %s", buf.String())
+	}
+}
+
+// Test_CaptureBody 测试记录header与body
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 7:55 下午 2026/10/19
+func Test_CaptureBody(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	router := gin.New()
+	router.Use(gw.AccessLogMiddleware(WithCaptureHeader(), WithCaptureBody(64), WithRedactKeyList("password", "X-Token")))
+	router.POST("/login", func(ctx *gin.Context) {
+		var param map[string]interface{}
+		if err := ctx.BindJSON(&param); nil != err {
+			t.Fatalf("业务读取body失败 : %v", err)
+		}
+		ctx.JSON(http.StatusOK, gin.H{"name": param["name"], "password": "response"})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"name":"zhang","password":"123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer abc")
+	req.Header.Set("X-Token", "abc")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	line := decodeTestLineList(t, buf)[0]
+	header := line["request_header"].(map[string]interface{})
+	if _, exist := header["Authorization"]; exist || header["X-Token"] != redactedValue {
+		t.Fatalf("header 过滤或脱敏错误 : %v", header)
+	}
+	if line["request_body"] != `{"name":"zhang","password":"***"}` || line["response_body"] != `{"name":"zhang","password":"***"}` {
+		t.Fatalf("body 记录或脱敏错误 : %s", buf.String())
+	}
+}
+
+// Test_RedactJSON 测试json body按key脱敏, 保持原有格式, 截断的body同样脱敏
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:20 上午 2026/10/21
+func Test_RedactJSON(t *testing.T) {
+	o := &AccessLogOption{RedactKeyList: []string{"pin", "password", "card"}}
+	o.initCaptureOption()
+	testCaseList := []struct {
+		body   string
+		expect string
+	}{
+		{
+			`{"b":1, "pin": 1234,"a":{"Password":null},"list":[{"card":{"no":1}},{"card":[1,2]}],"url":"<a>"}`,
+			`{"b":1, "pin": "***","a":{"Password":"***"},"list":[{"card":"***"},{"card":"***"}],"url":"<a>"}`,
+		},
+		{`{"name":"a","card":{"no":"6222`, `{"name":"a","card":"***"`},
+		{`{"name":"a","pin":"12`, `{"name":"a","pin":"***"`},
+		{"{\"pin\":1}\n{\"pin\":[2]}", "{\"pin\":\"***\"}\n{\"pin\":\"***\"}"},
+	}
+	for _, testCase := range testCaseList {
+		if result := o.redactJSON(testCase.body); result != testCase.expect {
+			t.Fatalf("json 脱敏错误, body : %s, 结果 : %s", testCase.body, result)
+		}
+	}
+}
+
+// Test_RecoveryMiddleware 测试panic捕获
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 8:35 下午 2026/10/19
+func Test_RecoveryMiddleware(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	router := gin.New()
+	router.Use(gw.RecoveryMiddleware(WithRecoveryHandler(func(ctx *gin.Context, recovered interface{}) {
+		ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"message": "busy"})
+	})))
+	router.GET("/panic", func(ctx *gin.Context) {
+		panic("boom")
+	})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	line := decodeTestLineList(t, buf)[0]
+	if resp.Code != http.StatusServiceUnavailable || line["panic"] != "boom" || line["level"] != "ERROR" {
+		t.Fatalf("panic 捕获错误 : %s", buf.String())
+	}
+	stack := line["stack"].([]interface{})
+	if len(stack) == 0 || !strings.Contains(stack[0].(map[string]interface{})["func"].(string), "Test_RecoveryMiddleware") {
+		t.Fatalf("panic 堆栈错误 : %v", stack)
+	}
+}
+
+// Test_GinWrapperCaller 测试日志记录的调用文件以及格式化日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:20 下午 2026/10/19
+func Test_GinWrapperCaller(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	child := gw.With(zap.String("module", "user"))
+	child.Info("info")
+	child.Infof("infof %d", 1)
+	child.Infow("infow", "uid", 10)
+	for _, line := range decodeTestLineList(t, buf) {
+		if !strings.HasPrefix(line["file"].(string), "wrapper/http_gin_test.go") || line["module"] != "user" {
+			t.Fatalf("调用文件错误 : %v", line)
+		}
+	}
+	if lineList := decodeTestLineList(t, buf); lineList[1]["message"] != "infof 1" || lineList[2]["uid"] != float64(10) {
+		t.Fatalf("格式化日志错误 : %s", buf.String())
+	}
+}
+
+// Test_ExtractField 测试从请求中抽取字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 9:50 下午 2026/10/19
+func Test_ExtractField(t *testing.T) {
+	gw, buf := newTestGinWrapper([]string{"user"},
+		WithExtractField(
+			ExtractField{Source: ExtractSourceHeader, Key: "X-Client", Rename: "client"},
+			ExtractField{Source: ExtractSourceQuery, Key: "tag"},
+			ExtractField{Source: ExtractSourceParam, Key: "id", Rename: "order_id"},
+			ExtractField{Source: ExtractSourceCookie, Key: "session"},
+		),
+		WithFieldExtractor(func(ctx *gin.Context) []zap.Field {
+			return []zap.Field{zap.Int("uid", ctx.GetInt("uid"))}
+		}),
+	)
+	router := gin.New()
+	router.GET("/order/:id", func(ctx *gin.Context) {
+		ctx.Set("user", map[string]interface{}{"age": 18})
+		ctx.Set("uid", 10)
+		gw.GetLogger(ctx).Info("handler")
+	})
+	req := httptest.NewRequest(http.MethodGet, "/order/100?tag=a&tag=b", nil)
+	req.Header.Set("X-Client", "ios")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	line := decodeTestLineList(t, buf)[0]
+	if line["user"].(map[string]interface{})["age"] != float64(18) || line["uid"] != float64(10) {
+		t.Fatalf("上下文字段类型错误 : %s", buf.String())
+	}
+	if line["client"] != "ios" || len(line["tag"].([]interface{})) != 2 || line["order_id"] != "100" || line["session"] != "s1" {
+		t.Fatalf("请求字段抽取错误 : %s", buf.String())
+	}
+}
+
+// Test_RoutePolicy 测试路由级别的日志策略
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:45 下午 2026/10/19
+func Test_RoutePolicy(t *testing.T) {
+	policyList, err := ParseRoutePolicyList([]byte(`[{"route":"/metrics","skip":true},{"method":"get","route":"/health*","sample_rate":3},{"route":"/order","min_level":"warn"}]`))
+	if nil != err {
+		t.Fatalf("路由日志策略解析失败 : %v", err)
+	}
+	if _, err := ParseRoutePolicyList([]byte(`[{"route":"/","min_level":"unknown"}]`)); nil == err {
+		t.Fatalf("错误的日志级别应解析失败")
+	}
+	gw, buf := newTestGinWrapper(nil)
+	router := gin.New()
+	router.Use(gw.AccessLogMiddleware(
+		WithRoutePolicy(RoutePolicy{Route: "/health/*", Skip: true, MinLevel: "unknown"}),
+		WithRoutePolicy(policyList...),
+		// 直接设置的策略同样生效
+		func(o *AccessLogOption) {
+			o.RoutePolicyList = append(o.RoutePolicyList, RoutePolicy{Route: "/ping", SampleRate: 2})
+		},
+	))
+	if !strings.Contains(buf.String(), "路由日志策略配置错误") {
+		t.Fatalf("错误的路由日志策略应记录错误日志 : %s", buf.String())
+	}
+	buf.Reset()
+	router.GET("/metrics", func(ctx *gin.Context) {})
+	router.GET("/health/live", func(ctx *gin.Context) {})
+	router.GET("/ping", func(ctx *gin.Context) {})
+	router.GET("/order", func(ctx *gin.Context) {
+		gw.GetLogger(ctx).Info("handler info")
+		gw.GetLogger(ctx).Warn("handler warn")
+	})
+
+	for _, path := range []string{"/metrics", "/health/live", "/health/live", "/health/live", "/health/live", "/order", "/ping", "/ping"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	lineList := decodeTestLineList(t, buf)
+	if len(lineList) != 4 {
+		t.Fatalf("路由日志策略错误 : %s", buf.String())
+	}
+	if lineList[0]["path"] != "/health/live" || lineList[1]["path"] != "/health/live" || lineList[2]["message"] != "handler warn" || lineList[3]["path"] != "/ping" {
+		t.Fatalf("路由日志策略错误 : %s", buf.String())
+	}
+}
+
+// Test_DebugLogMiddleware 测试请求级别的Debug日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:30 下午 2026/10/19
+func Test_DebugLogMiddleware(t *testing.T) {
+	buf, debugBuf := &bytes.Buffer{}, &bytes.Buffer{}
+	core := zapcore.NewCore(logger.GetEncoder(), zapcore.AddSync(buf), zapcore.InfoLevel)
+	debugLogger := zap.New(zapcore.NewCore(logger.GetEncoder(), zapcore.AddSync(debugBuf), zapcore.DebugLevel))
+	gw := NewGinWrapperFromLogger(zap.New(core, zap.AddCaller()), nil, WithDebugLogger(debugLogger))
+	router := gin.New()
+	router.Use(DebugLogMiddleware("secret"))
+	router.GET("/", func(ctx *gin.Context) {
+		l := gw.GetLogger(ctx).With(zap.String("module", "user"))
+		l.Debug("handler debug")
+		l.Info("handler info")
+	})
+
+	for _, path := range []string{"/", "/?debug_log=wrong", "/?debug_log=secret"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(defaultDebugLogHeader, "secret")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if lineList := decodeTestLineList(t, buf); len(lineList) != 4 || lineList[0]["message"] != "handler info" {
+		t.Fatalf("普通日志错误 : %s", buf.String())
+	}
+	debugLineList := decodeTestLineList(t, debugBuf)
+	if len(debugLineList) != 2 || debugLineList[0]["message"] != "handler debug" || debugLineList[0]["module"] != "user" {
+		t.Fatalf("Debug日志错误 : %s", debugBuf.String())
+	}
+}
+
+// Test_DebugLogSecretRedact 测试开启Debug日志的密钥不会出现在访问日志中
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 10:20 上午 2026/10/21
+func Test_DebugLogSecretRedact(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	textBuf := &bytes.Buffer{}
+	router := gin.New()
+	router.Use(
+		gw.AccessLogMiddleware(WithCaptureHeader()),
+		gw.AccessLogMiddleware(WithTextLog(TextLogFormatCommon, textBuf)),
+		DebugLogMiddleware("s3cret", WithDebugLogHeader("X-Debug-Token"), WithDebugLogQuery("debug_token")),
+	)
+	router.GET("/", func(ctx *gin.Context) {})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?a=1&debug_token=s3cret", nil))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Debug-Token", "s3cret")
+	req.Header.Set("X-Other", "1")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if output := buf.String() + textBuf.String(); strings.Contains(output, "s3cret") {
+		t.Fatalf("访问日志记录了Debug日志的密钥 : %s", output)
+	}
+	lineList := decodeTestLineList(t, buf)
+	if len(lineList) != 2 || lineList[0]["query"] != "a=1&debug_token=***" {
+		t.Fatalf("query中的密钥未隐藏 : %s", buf.String())
+	}
+	if requestHeader := lineList[1]["request_header"].(map[string]interface{}); requestHeader["X-Other"] != "1" {
+		t.Fatalf("其他header不应被过滤 : %s", buf.String())
+	}
+	if !strings.Contains(textBuf.String(), `"GET /?a=1&debug_token=*** HTTP/1.1"`) {
+		t.Fatalf("文本访问日志中的密钥未隐藏 : %s", textBuf.String())
+	}
+}
+
+// Test_CanonicalLog 测试请求日志合并到访问日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:10 上午 2026/10/20
+func Test_CanonicalLog(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), gw.AccessLogMiddleware(WithCanonicalLog()))
+	router.GET("/order", func(ctx *gin.Context) {
+		l := gw.GetLogger(ctx)
+		l.AddField(zap.Int("uid", 10))
+		l.With(zap.String("module", "order")).Info("load order", zap.Int("items", 2), zap.Int("status", 7))
+		l.Debugf("cost %d", 3)
+		l.Warn("stock low")
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/order", nil))
+
+	lineList := decodeTestLineList(t, buf)
+	if len(lineList) != 2 || lineList[0]["message"] != "stock low" {
+		t.Fatalf("合并模式日志数量错误 : %s", buf.String())
+	}
+	line := lineList[1]
+	if line["uid"] != float64(10) || line["module"] != "order" || line["items"] != float64(2) || len(line["messages"].([]interface{})) != 2 {
+		t.Fatalf("合并模式字段错误 : %v", line)
+	}
+	if line["warn_count"] != float64(1) || line["error_count"] != float64(0) {
+		t.Fatalf("合并模式日志计数错误 : %v", line)
+	}
+	// 请求字段不重复记录, 与访问日志重复的字段增加后缀
+	accessLine := strings.Split(strings.TrimSpace(buf.String()), "\n")[1]
+	if strings.Count(accessLine, `"`+RequestIDField+`":`) != 1 || strings.Count(accessLine, `"status":`) != 1 {
+		t.Fatalf("合并模式存在重复的字段 : %s", accessLine)
+	}
+	if line["status"] != float64(http.StatusOK) || line["status_1"] != float64(7) {
+		t.Fatalf("合并模式重复字段处理错误 : %s", accessLine)
+	}
+}
+
+// Test_TailBuffer 测试请求日志缓存, 请求失败时才输出
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 12:50 上午 2026/10/20
+func Test_TailBuffer(t *testing.T) {
+	buf := &bytes.Buffer{}
+	core := zapcore.NewCore(logger.GetEncoder(), zapcore.AddSync(buf), zapcore.DebugLevel)
+	gw := NewGinWrapperFromLogger(withMinLevel(zap.New(core), zapcore.InfoLevel), nil, WithDebugLogger(zap.New(core)))
+	router := gin.New()
+	router.Use(gw.AccessLogMiddleware(WithTailBuffer(2)))
+	router.GET("/ok", func(ctx *gin.Context) {
+		gw.GetLogger(ctx).Debug("ok debug")
+		gw.GetLogger(ctx).Info("ok info")
+	})
+	router.GET("/fail", func(ctx *gin.Context) {
+		l := gw.GetLogger(ctx)
+		l.Debug("fail debug 1")
+		l.Debug("fail debug 2")
+		l.Info("fail info")
+		ctx.Status(http.StatusInternalServerError)
+	})
+	router.GET("/error", func(ctx *gin.Context) {
+		l := gw.GetLogger(ctx)
+		l.Info("error info")
+		l.Error("error log")
+		l.Info("after error")
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	if lineList := decodeTestLineList(t, buf); len(lineList) != 1 || lineList[0]["message"] != defaultAccessLogMessage {
+		t.Fatalf("成功的请求不应输出缓存 : %s", buf.String())
+	}
+
+	buf.Reset()
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	lineList := decodeTestLineList(t, buf)
+	if len(lineList) != 3 || lineList[0]["message"] != "fail debug 2" || lineList[1]["message"] != "fail info" || lineList[2]["tail_dropped"] != float64(1) {
+		t.Fatalf("失败的请求输出缓存错误 : %s", buf.String())
+	}
+
+	buf.Reset()
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/error", nil))
+	if lineList := decodeTestLineList(t, buf); len(lineList) != 4 || lineList[0]["message"] != "error info" || lineList[1]["message"] != "error log" || lineList[2]["message"] != "after error" {
+		t.Fatalf("Error日志输出缓存错误 : %s", buf.String())
+	}
+}
+
+// Test_AccessLogPanic 测试请求中 panic 时输出缓存的日志并记录访问日志, recovery 在访问日志中间件之外
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:20 下午 2026/10/21
+func Test_AccessLogPanic(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	router := gin.New()
+	router.Use(gw.RecoveryMiddleware(), gw.AccessLogMiddleware(WithTailBuffer(2)))
+	router.GET("/panic", func(ctx *gin.Context) {
+		gw.GetLogger(ctx).Info("before panic")
+		panic("boom")
+	})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	lineList := decodeTestLineList(t, buf)
+	if resp.Code != http.StatusInternalServerError || len(lineList) != 3 {
+		t.Fatalf("panic 的请求日志数量错误 : %s", buf.String())
+	}
+	if lineList[0]["message"] != "before panic" || lineList[1]["message"] != defaultAccessLogMessage || lineList[1]["status"] != float64(http.StatusInternalServerError) || lineList[2]["panic"] != "boom" {
+		t.Fatalf("panic 的请求日志错误 : %s", buf.String())
+	}
+}
+
+// Test_GinErrors 测试访问日志记录 ctx.Error 设置的错误
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:25 上午 2026/10/20
+func Test_GinErrors(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	router := gin.New()
+	router.Use(gw.AccessLogMiddleware(), gw.RecoveryMiddleware())
+	router.GET("/bind", func(ctx *gin.Context) {
+		_ = ctx.Error(errors.New("invalid id")).SetType(gin.ErrorTypeBind).SetMeta(gin.H{"field": "id"})
+	})
+	router.GET("/private", func(ctx *gin.Context) {
+		_ = ctx.Error(pkgerrors.Wrap(io.ErrUnexpectedEOF, "read upstream"))
+	})
+	router.GET("/broken", func(ctx *gin.Context) {
+		panic(&net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)})
+	})
+	router.GET("/any", func(ctx *gin.Context) {
+		_ = ctx.Error(errors.New("any")).SetType(gin.ErrorTypeAny)
+		_ = ctx.Error(errors.New("bind public")).SetType(gin.ErrorTypeBind | gin.ErrorTypePublic)
+	})
+	for _, path := range []string{"/bind", "/private", "/broken", "/any"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	lineList := decodeTestLineList(t, buf)
+	bindErr := lineList[0]["errors"].([]interface{})[0].(map[string]interface{})
+	if lineList[0]["level"] != "WARN" || bindErr["type"] != "bind" || bindErr["meta"].(map[string]interface{})["field"] != "id" {
+		t.Fatalf("bind 错误记录错误 : %v", lineList[0])
+	}
+	privateErr := lineList[1]["errors"].([]interface{})[0].(map[string]interface{})
+	if lineList[1]["level"] != "ERROR" || privateErr["type"] != "private" || privateErr["cause"] != io.ErrUnexpectedEOF.Error() {
+		t.Fatalf("private 错误记录错误 : %v", lineList[1])
+	}
+	// 客户端断开连接使用Warn级别, 不提升为Error
+	if len(lineList) != 5 || lineList[2]["message"] != brokenPipeMessage || lineList[3]["level"] != "WARN" {
+		t.Fatalf("客户端断开连接的访问日志级别错误 : %s", buf.String())
+	}
+	// ErrorTypeAny 包含全部的位, 不能按 bind 处理
+	errList := lineList[4]["errors"].([]interface{})
+	if lineList[4]["level"] != "ERROR" || errList[0].(map[string]interface{})["type"] != "any" || errList[1].(map[string]interface{})["type"] != "bind|public" {
+		t.Fatalf("错误类型记录错误 : %v", lineList[4])
+	}
+}
+
+// Test_RouteLogFile 测试路由分组的日志写入单独的日志文件
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 1:58 上午 2026/10/20
+func Test_RouteLogFile(t *testing.T) {
+	logPath, err := ioutil.TempDir("", "route_log")
+	if nil != err {
+		t.Fatalf("创建临时目录失败 : %v", err)
+	}
+	defer func() { _ = os.RemoveAll(logPath) }()
+	splitConfig, err := logger.NewRotateLogConfig(logPath, "pay.log")
+	if nil != err {
+		t.Fatalf("日志切割配置错误 : %v", err)
+	}
+	buf, debugBuf := &bytes.Buffer{}, &bytes.Buffer{}
+	core := zapcore.NewCore(logger.GetEncoder(), zapcore.AddSync(buf), zapcore.InfoLevel)
+	debugLogger := zap.New(zapcore.NewCore(logger.GetEncoder(), zapcore.AddSync(debugBuf), zapcore.DebugLevel))
+	gw := NewGinWrapperFromLogger(zap.New(core, zap.AddCaller()), nil, WithRouteLogFile("/pay", splitConfig), WithDebugLogger(debugLogger))
+	router := gin.New()
+	router.Use(DebugLogMiddleware("secret"))
+	pay := router.Group("/pay")
+	pay.POST("/order/:id", func(ctx *gin.Context) {
+		gw.GetLogger(ctx).Debug("pay debug")
+		gw.GetLogger(ctx).Info("pay order")
+	})
+	router.POST("/payment", func(ctx *gin.Context) {
+		gw.GetLogger(ctx).Info("payment")
+	})
+	for _, path := range []string{"/pay/order/1", "/pay/order/2?debug_log=secret", "/payment"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, path, nil))
+	}
+
+	if lineList := decodeTestLineList(t, buf); len(lineList) != 1 || lineList[0]["message"] != "payment" {
+		t.Fatalf("原有日志文件记录错误 : %s", buf.String())
+	}
+	if debugBuf.Len() > 0 {
+		t.Fatalf("路由分组的Debug日志不应写入 DebugLogger : %s", debugBuf.String())
+	}
+	fileList, _ := filepath.Glob(filepath.Join(logPath, "*pay.log"))
+	if len(fileList) != 1 {
+		t.Fatalf("路由分组日志文件数量错误 : %v", fileList)
+	}
+	byteData, _ := ioutil.ReadFile(fileList[0])
+	if lineList := decodeTestLineList(t, bytes.NewBuffer(byteData)); len(lineList) != 3 || lineList[0]["message"] != "pay order" || lineList[1]["message"] != "pay debug" || lineList[2]["message"] != "pay order" {
+		t.Fatalf("路由分组日志记录错误 : %s", string(byteData))
+	}
+
+	// 日志文件创建失败时记录错误, 使用原有实例
+	gw, buf = newTestGinWrapper(nil, WithRouteLogFile("/pay", &logger.RotateLogConfig{FullLogFormat: filepath.Join(logPath, "%Q.log")}))
+	router = gin.New()
+	router.POST("/pay/order", func(ctx *gin.Context) {
+		gw.GetLogger(ctx).Info("pay order")
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/pay/order", nil))
+	if lineList := decodeTestLineList(t, buf); len(lineList) != 2 || lineList[0]["path_prefix"] != "/pay" || lineList[1]["message"] != "pay order" {
+		t.Fatalf("路由分组日志文件创建失败的处理错误 : %s", buf.String())
+	}
+}
+
+// Test_TextLog 测试 Combined 格式的访问日志
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 2:30 上午 2026/10/20
+func Test_TextLog(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	textBuf := &bytes.Buffer{}
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), gw.AccessLogMiddleware(WithTextLog(TextLogFormatCombined, textBuf, TextLogFieldRequestID, TextLogFieldLatency)))
+	router.GET("/user/:id", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, "ok")
+	})
+	req := httptest.NewRequest(http.MethodGet, "/user/1?q=a", nil)
+	req.RemoteAddr = "1.2.3.4:1234"
+	req.SetBasicAuth("zhang", "123456")
+	req.Header.Set("User-Agent", `curl "7.0"`)
+	req.Header.Set(defaultRequestIDHeader, "abc")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if buf.Len() > 0 {
+		t.Fatalf("文本访问日志模式不应记录json访问日志 : %s", buf.String())
+	}
+	line := textBuf.String()
+	if !strings.HasPrefix(line, "1.2.3.4 - zhang [") || !strings.Contains(line, `] "GET /user/1?q=a HTTP/1.1" 200 2 "-" "curl \"7.0\"" "abc" 0.`) || !strings.HasSuffix(line, "\n") {
+		t.Fatalf("文本访问日志格式错误 : %s", line)
+	}
+
+	// 文本格式无法记录的错误与追加的字段, 仍然记录json日志
+	textBuf.Reset()
+	router.GET("/order", func(ctx *gin.Context) {
+		gw.GetLogger(ctx).AddField(zap.String("order_id", "o1"))
+		_ = ctx.Error(errors.New("create order")).SetType(gin.ErrorTypePrivate)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/order", nil))
+	lineList := decodeTestLineList(t, buf)
+	if !strings.Contains(textBuf.String(), `"GET /order HTTP/1.1" 200`) || len(lineList) != 1 {
+		t.Fatalf("文本访问日志错误 : %s, json日志 : %s", textBuf.String(), buf.String())
+	}
+	if lineList[0]["level"] != "ERROR" || lineList[0]["order_id"] != "o1" || len(lineList[0]["errors"].([]interface{})) != 1 {
+		t.Fatalf("文本访问日志模式下的错误与追加字段记录错误 : %s", buf.String())
+	}
+}
+
+// Test_TextLogFile 测试文本访问日志写入单独的日志文件
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 11:35 上午 2026/10/21
+func Test_TextLogFile(t *testing.T) {
+	logPath, err := ioutil.TempDir("", "text_log")
+	if nil != err {
+		t.Fatalf("创建临时目录失败 : %v", err)
+	}
+	defer func() { _ = os.RemoveAll(logPath) }()
+	splitConfig, err := logger.NewRotateLogConfig(logPath, "access.log")
+	if nil != err {
+		t.Fatalf("日志切割配置错误 : %v", err)
+	}
+	gw, buf := newTestGinWrapper(nil)
+	router := gin.New()
+	router.Use(gw.AccessLogMiddleware(WithTextLogFile(TextLogFormatCommon, splitConfig)))
+	router.GET("/", func(ctx *gin.Context) {})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if buf.Len() > 0 {
+		t.Fatalf("文本访问日志模式不应记录json访问日志 : %s", buf.String())
+	}
+	fileList, _ := filepath.Glob(filepath.Join(logPath, "*access.log"))
+	if len(fileList) != 1 {
+		t.Fatalf("文本访问日志文件数量错误 : %v", fileList)
+	}
+	if byteData, _ := ioutil.ReadFile(fileList[0]); !strings.Contains(string(byteData), `"GET / HTTP/1.1" 200 -`) {
+		t.Fatalf("文本访问日志文件内容错误 : %s", string(byteData))
+	}
+
+	// 日志文件创建失败时记录错误, 使用json格式记录访问日志
+	gw, buf = newTestGinWrapper(nil)
+	router = gin.New()
+	router.Use(gw.AccessLogMiddleware(WithTextLogFile(TextLogFormatCommon, &logger.RotateLogConfig{FullLogFormat: filepath.Join(logPath, "%Q.log")})))
+	router.GET("/", func(ctx *gin.Context) {})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if lineList := decodeTestLineList(t, buf); len(lineList) != 2 || lineList[0]["level"] != "ERROR" || lineList[1]["message"] != defaultAccessLogMessage {
+		t.Fatalf("文本访问日志文件创建失败的处理错误 : %s", buf.String())
+	}
+}
+
+// Test_ContextMiddleware 测试在gin上下文以及 context.Context 中获取请求的日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:00 上午 2026/10/20
+func Test_ContextMiddleware(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), gw.ContextMiddleware())
+	router.GET("/", func(ctx *gin.Context) {
+		FromGin(ctx).Info("handler")
+		logger.FromContext(ctx.Request.Context()).Info("service")
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(defaultRequestIDHeader, "abc")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	lineList := decodeTestLineList(t, buf)
+	if len(lineList) != 2 || lineList[0][RequestIDField] != "abc" || lineList[1][RequestIDField] != "abc" || lineList[1]["message"] != "service" {
+		t.Fatalf("请求日志实例错误 : %s", buf.String())
+	}
+	if !strings.HasPrefix(lineList[0]["file"].(string), "wrapper/http_gin_test.go") || !strings.HasPrefix(lineList[1]["file"].(string), "wrapper/http_gin_test.go") {
+		t.Fatalf("调用文件错误 : %s", buf.String())
+	}
+}
+
+// Test_RequestFieldCache 测试请求字段只抽取一次, gin上下文数据变化时重新抽取
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:35 上午 2026/10/20
+func Test_RequestFieldCache(t *testing.T) {
+	extractCount := 0
+	gw, buf := newTestGinWrapper([]string{"uid"}, WithFieldExtractor(func(ctx *gin.Context) []zap.Field {
+		extractCount++
+		return nil
+	}))
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	l := gw.GetLogger(ctx)
+	l.Info("first")
+	l.Infof("second")
+	ctx.Set("uid", 10)
+	l.Infow("third")
+	if lineList := decodeTestLineList(t, buf); extractCount != 2 || nil != lineList[1]["uid"] || lineList[2]["uid"] != float64(10) {
+		t.Fatalf("请求字段缓存错误, 抽取次数 : %d, 日志 : %s", extractCount, buf.String())
+	}
+
+	// 覆盖已有的数据同样重新抽取
+	buf.Reset()
+	ctx.Set("uid", 20)
+	l.Info("fourth")
+	l.Info("fifth")
+	if lineList := decodeTestLineList(t, buf); extractCount != 3 || lineList[0]["uid"] != float64(20) || lineList[1]["uid"] != float64(20) {
+		t.Fatalf("覆盖数据后请求字段缓存错误, 抽取次数 : %d, 日志 : %s", extractCount, buf.String())
+	}
+	ctx.Set("other", 1)
+	ctx.Set("uid", []int{1})
+	l.Info("sixth")
+	l.Info("seventh")
+	ctx.Set("uid", []int{1})
+	l.Info("eighth")
+	l.Refresh()
+	l.Info("ninth")
+	if extractCount != 6 {
+		t.Fatalf("重新设置切片或者手动刷新后应重新抽取, 抽取次数 : %d", extractCount)
+	}
+
+	// 无法直接比较的值内容不变时不重新抽取
+	ctx.Set("uid", struct{ List []int }{List: []int{1}})
+	l.Info("tenth")
+	l.Info("eleventh")
+	if extractCount != 7 {
+		t.Fatalf("无法直接比较的值不应每次重新抽取, 抽取次数 : %d", extractCount)
+	}
+
+	// 记录日志的同时其他协程设置gin上下文数据
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			ctx.Set("other", i)
+			ctx.Set("uid", i)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		l.Info("concurrent")
+	}
+	<-done
+}
+
+// newBenchmarkGinContext 生成携带抽取字段的gin上下文
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:40 上午 2026/10/20
+func newBenchmarkGinContext() (*GinWrapper, *gin.Context) {
+	core := zapcore.NewCore(logger.GetEncoder(), zapcore.AddSync(ioutil.Discard), zapcore.DebugLevel)
+	gw := NewGinWrapperFromLogger(zap.New(core, zap.AddCaller()), []string{"user", "uid"},
+		WithExtractField(ExtractField{Source: ExtractSourceHeader, Key: "X-Client"}, ExtractField{Source: ExtractSourceQuery, Key: "tag"}))
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/?tag=a", nil)
+	ctx.Request.Header.Set("X-Client", "ios")
+	ctx.Set("user", map[string]interface{}{"name": "zhang", "age": 18})
+	ctx.Set("uid", 10)
+	return gw, ctx
+}
+
+// Benchmark_GinWrapperInfo 同一个请求多次记录日志, 字段只抽取一次
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:42 上午 2026/10/20
+func Benchmark_GinWrapperInfo(b *testing.B) {
+	gw, ctx := newBenchmarkGinContext()
+	l := gw.GetLogger(ctx)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info("benchmark", zap.Int("i", i))
+	}
+}
+
+// Benchmark_GinWrapperInfoExtractEveryCall 每次记录日志都重新抽取字段, 作为对比
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 3:44 上午 2026/10/20
+func Benchmark_GinWrapperInfoExtractEveryCall(b *testing.B) {
+	gw, ctx := newBenchmarkGinContext()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		gw.GetLogger(ctx).Info("benchmark", zap.Int("i", i))
+	}
+}
+
+// Test_RedirectGinLog 测试gin框架的输出重定向到日志实例
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 4:25 上午 2026/10/20
+func Test_RedirectGinLog(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	restore := gw.RedirectGinLog(WithGinRouteLevel(zapcore.InfoLevel))
+	gin.SetMode(gin.DebugMode)
+	defer func() {
+		gin.SetMode(gin.TestMode)
+		restore()
+	}()
+	router := gin.New()
+	router.GET("/user/:id", func(ctx *gin.Context) {})
+	_, _ = gin.DefaultErrorWriter.Write([]byte("[GIN-debug] [ERROR] listen fail\n"))
+
+	lineList := decodeTestLineList(t, buf)
+	if len(lineList) != 5 {
+		t.Fatalf("gin框架输出重定向数量错误 : %s", buf.String())
+	}
+	if lineList[0]["level"] != "WARN" || lineList[0]["component"] != "gin" || nil != lineList[0]["file"] || lineList[1]["level"] != "DEBUG" {
+		t.Fatalf("gin框架调试信息重定向错误 : %s", buf.String())
+	}
+	if lineList[3]["message"] != ginRouteMessage || lineList[3]["level"] != "INFO" || lineList[3]["path"] != "/user/:id" || lineList[3]["method"] != http.MethodGet {
+		t.Fatalf("gin路由注册重定向错误 : %v", lineList[3])
+	}
+	if lineList[4]["message"] != "listen fail" || lineList[4]["level"] != "ERROR" {
+		t.Fatalf("gin框架错误重定向错误 : %v", lineList[4])
+	}
+}
+
+// Test_TraceMiddleware 测试gin请求的日志携带链路追踪字段
+//
+// Author : go_developer@163.com<张德满>
+//
+// Date : 5:55 上午 2026/10/20
+func Test_TraceMiddleware(t *testing.T) {
+	gw, buf := newTestGinWrapper(nil)
+	router := gin.New()
+	router.Use(TraceMiddleware(), gw.ContextMiddleware())
+	router.GET("/", func(ctx *gin.Context) {
+		if tc, ok := logger.TraceFromContext(ctx.Request.Context()); !ok || tc.SpanID != "00f067aa0ba902b7" {
+			t.Fatalf("请求 context 中的链路追踪信息错误 : %v", tc)
+		}
+		gw.GetLogger(ctx).Info("handler")
+		logger.Ctx(ctx.Request.Context()).Info("service")
+		logger.FromContext(ctx.Request.Context()).Info("from context")
+	})
+	// 不使用 ContextMiddleware 时, logger.Ctx 同样携带链路追踪字段
+	zapLogger := zap.New(zapcore.NewCore(logger.GetEncoder(), zapcore.AddSync(buf), zapcore.DebugLevel))
+	router.GET("/ctx", func(ctx *gin.Context) {
+		logger.Ctx(logger.NewContext(ctx.Request.Context(), zapLogger)).Info("ctx")
+	})
+	for _, path := range []string{"/", "/ctx"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(logger.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	lineList := decodeTestLineList(t, buf)
+	if len(lineList) != 4 {
+		t.Fatalf("链路追踪日志数量错误 : %s", buf.String())
+	}
+	for _, line := range lineList {
+		if line["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" || line["sampled"] != false {
+			t.Fatalf("链路追踪字段错误 : %s", buf.String())
+		}
+	}
+	if strings.Count(buf.String(), `"trace_id"`) != 4 {
+		t.Fatalf("链路追踪字段重复 : %s", buf.String())
+	}
+}